@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/apidiff"
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/gomod"
+	"github.com/AkaraChen/tagger/internal/ui"
+)
+
+// analyzeAPI 比较 dir 下的 Go 模块在 previousTag 和 HEAD 导出的 API，返回建议的更新类型
+// 两个版本都检出到临时的 git worktree 中，不访问网络；没有上个版本或 dir 下没有 go.mod 时返回 nil
+func analyzeAPI(gitClient *git.GitClient, repoRoot, dir, previousTag string) (*ui.Recommendation, error) {
+	if previousTag == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, dir, gomod.FileName)); err != nil {
+		return nil, nil
+	}
+
+	// 模块目录中没有修改 Go 文件时 API 不会变化，不需要检出两个版本
+	changed, err := hasGoChanges(gitClient, dir, previousTag)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return &ui.Recommendation{
+			Type:   apidiff.None.BumpType(),
+			Reason: fmt.Sprintf("no Go source changes since %s", previousTag),
+		}, nil
+	}
+
+	tmp, err := os.MkdirTemp("", "tagger-apidiff-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	load := func(name, ref string) (apidiff.API, error) {
+		worktree := filepath.Join(tmp, name)
+		if err := gitClient.AddWorktree(worktree, ref); err != nil {
+			return nil, err
+		}
+		defer gitClient.RemoveWorktree(worktree)
+
+		// 上个版本中还不存在的模块视为没有 API
+		moduleDir := filepath.Join(worktree, dir)
+		if _, err := os.Stat(moduleDir); err != nil {
+			return apidiff.API{}, nil
+		}
+		return apidiff.Load(moduleDir)
+	}
+
+	oldAPI, err := load("old", previousTag)
+	if err != nil {
+		return nil, err
+	}
+	newAPI, err := load("new", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	report := apidiff.Compare(oldAPI, newAPI)
+	level := report.Level()
+
+	var reason string
+	switch level {
+	case apidiff.Incompatible:
+		reason = fmt.Sprintf("incompatible API changes since %s", previousTag)
+	case apidiff.Compatible:
+		reason = fmt.Sprintf("API additions since %s", previousTag)
+	default:
+		reason = fmt.Sprintf("no API changes since %s", previousTag)
+	}
+
+	return &ui.Recommendation{
+		Type:   level.BumpType(),
+		Reason: reason,
+		Report: report.String(),
+	}, nil
+}
+
+// hasGoChanges 判断 previousTag 和 HEAD 之间 dir 下是否有 .go 文件的修改
+// 重命名在 numstat 中显示为 {old => new} 的形式，无法判断目录时视为有修改
+func hasGoChanges(gitClient *git.GitClient, dir, previousTag string) (bool, error) {
+	files, err := gitClient.GetChangedFiles(previousTag, "HEAD")
+	if err != nil {
+		return false, err
+	}
+	dir = strings.Trim(path.Clean(filepath.ToSlash(dir)), "/")
+	if dir == "." {
+		dir = ""
+	}
+	for _, file := range files {
+		if !strings.Contains(file.Path, ".go") {
+			continue
+		}
+		if dir == "" || strings.HasPrefix(file.Path, dir+"/") || strings.Contains(file.Path, "=>") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configShowOrigin bool
+	configGlobal     bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "查看和管理配置",
+	Long: `查看和管理 tagger 的配置
+
+配置按以下优先级合并（后者覆盖前者）：
+  1. 默认值
+  2. 用户级配置：$XDG_CONFIG_HOME/tagger/config.{json,yaml,yml,toml}（默认 ~/.config/tagger）
+  3. 项目配置：git 仓库根目录下的 tagger.config.{json,yaml,yml,toml}、package.json 中的
+     tagger 字段，或 --config 指定的文件；同一目录下只能存在一个配置文件
+  4. 环境变量：TAGGER_*，例如 TAGGER_GITHUB_OPEN_ACTION_PAGE=false
+
+get、set 和 unset 使用点分隔的 key，例如 github.openActionPage；
+默认修改项目配置（或 --config 指定的文件），使用 --global 修改用户级配置`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "读取配置项",
+	Long:  `读取合并所有来源后生效的配置项，使用 --global 只读取用户级配置`,
+	Example: `  tagger config get gitHostingProvider
+  tagger config get github.openActionPage`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigGet(args[0], configGlobal)
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "设置配置项",
+	Long: `设置配置项，值按照 schema 中的类型解析并校验
+
+数组和对象使用 JSON 表示；文件不存在时会创建带有 $schema 的 JSON 配置文件`,
+	Example: `  tagger config set github.openActionPage false
+  tagger config set --global messageEditor true
+  tagger config set packages '[{"name":"api","path":"api"}]'`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSet(args[0], args[1], configGlobal)
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:          "unset <key>",
+	Short:        "删除配置项",
+	Long:         `删除配置项，删除后为空的父对象也会被删除`,
+	Example:      `  tagger config unset github.openActionPage`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigUnset(args[0], configGlobal)
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:          "show",
+	Short:        "显示生效的配置",
+	Long:         `显示合并所有来源后生效的配置，使用 --origin 显示每个配置项的来源`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigShow(configShowOrigin)
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "校验配置",
+	Long:  `按照 tagger.schema.json 校验所有配置来源（JSON、YAML、TOML 和 package.json 使用相同的 schema），发现问题时以非零状态码退出，适用于 CI`,
+	Args:  cobra.NoArgs,
+	// 校验失败不是用法错误，不需要打印帮助
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigValidate()
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "输出配置的 JSON Schema",
+	Long:  `输出根据配置类型生成的 JSON Schema，仓库中的 tagger.schema.json 由该命令生成`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSchema()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configShowCmd.Flags().BoolVar(&configShowOrigin, "origin", false, "显示每个配置项的来源")
+	for _, c := range []*cobra.Command{configGetCmd, configSetCmd, configUnsetCmd} {
+		c.Flags().BoolVar(&configGlobal, "global", false, "使用用户级配置文件")
+	}
+}
+
+func runConfigShow(showOrigin bool) error {
+	resolved, err := config.Resolve(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !showOrigin {
+		data, err := json.MarshalIndent(resolved.Nested(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(resolved.Files) == 0 {
+		fmt.Println(ui.HelpStyle.Render("No config files found"))
+	}
+	for _, file := range resolved.Files {
+		fmt.Println(ui.HelpStyle.Render(fmt.Sprintf("Loaded %s", file)))
+	}
+	fmt.Println()
+
+	for _, key := range resolved.Keys() {
+		value, err := json.Marshal(resolved.Values[key])
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", key, err)
+		}
+
+		fmt.Printf("%s = %s  %s\n",
+			ui.SelectedStyle.Render(key),
+			string(value),
+			ui.HelpStyle.Render(resolved.Origins[key].String()),
+		)
+	}
+
+	return nil
+}
+
+func runConfigValidate() error {
+	resolved, err := config.Resolve(configPath)
+	if err != nil {
+		return reportProblems(err)
+	}
+
+	if len(resolved.Files) == 0 {
+		fmt.Println(ui.InfoStyle.Render("No config files found"))
+		return nil
+	}
+
+	for _, file := range resolved.Files {
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ %s is valid", file)))
+	}
+	return nil
+}
+
+func runConfigSchema() error {
+	data, err := config.GenerateSchemaJSON()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// reportProblems 逐条输出配置校验错误，其他错误原样返回
+func reportProblems(err error) error {
+	var problems config.ValidationErrors
+	if !errors.As(err, &problems) {
+		return err
+	}
+
+	for _, problem := range problems {
+		fmt.Println(ui.ErrorStyle.Render("✗ ") + problem.Error())
+	}
+	return fmt.Errorf("configuration has %d problem(s)", len(problems))
+}
+
+// configTarget 返回 set 和 unset 修改的配置文件
+func configTarget(global bool) (string, error) {
+	if global {
+		return config.UserConfigPath()
+	}
+	if configPath != "" {
+		return configPath, nil
+	}
+
+	existing, err := config.FindProjectConfig()
+	if err != nil {
+		return "", err
+	}
+	if existing != "" {
+		return existing, nil
+	}
+	return config.ProjectConfigPath(), nil
+}
+
+func runConfigGet(key string, global bool) error {
+	var (
+		value any
+		ok    bool
+	)
+
+	if global {
+		path, err := config.UserConfigPath()
+		if err != nil {
+			return err
+		}
+		value, ok, err = config.FileValue(path, key)
+		if err != nil {
+			return err
+		}
+	} else {
+		resolved, err := config.Resolve(configPath)
+		if err != nil {
+			return reportProblems(err)
+		}
+		value, ok = resolved.Lookup(key)
+	}
+
+	if !ok {
+		return fmt.Errorf("%s is not set", key)
+	}
+
+	// 字符串直接输出，便于在脚本中使用
+	if str, isString := value.(string); isString {
+		fmt.Println(str)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func runConfigSet(key, value string, global bool) error {
+	path, err := configTarget(global)
+	if err != nil {
+		return err
+	}
+
+	if err := config.SetValue(path, key, value); err != nil {
+		return reportProblems(err)
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Set %s in %s", key, path)))
+	return nil
+}
+
+func runConfigUnset(key string, global bool) error {
+	path, err := configTarget(global)
+	if err != nil {
+		return err
+	}
+
+	removed, err := config.UnsetValue(path, key)
+	if err != nil {
+		return reportProblems(err)
+	}
+	if !removed {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("%s is not set in %s", key, path)))
+		return nil
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Unset %s in %s", key, path)))
+	return nil
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AkaraChen/tagger/internal/changelog"
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/semver"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <from> [to]",
+	Short: "显示两个版本之间的变更",
+	Long:  `列出两个版本（或版本与 HEAD）之间的 commits、变更文件、贡献者以及比较链接，省略 to 时与 HEAD 比较`,
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		to := "HEAD"
+		if len(args) == 2 {
+			to = args[1]
+		}
+		return runDiff(args[0], to, diffFormat)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "输出格式：text、markdown 或 json")
+}
+
+// diffRef 表示 diff 的一端
+type diffRef struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Commit  string `json:"commit"`
+}
+
+// diffCommit 表示 JSON 输出中的单个 commit
+type diffCommit struct {
+	Hash     string    `json:"hash"`
+	Scope    string    `json:"scope,omitempty"`
+	Subject  string    `json:"subject"`
+	Breaking bool      `json:"breaking,omitempty"`
+	Author   string    `json:"author"`
+	Date     time.Time `json:"date"`
+}
+
+// diffGroup 表示 JSON 输出中的 commit 分组
+type diffGroup struct {
+	Type    string       `json:"type"`
+	Title   string       `json:"title"`
+	Commits []diffCommit `json:"commits"`
+}
+
+// diffFile 表示 JSON 输出中的文件变更
+type diffFile struct {
+	Path      string `json:"path"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary,omitempty"`
+}
+
+// diffContributor 表示 JSON 输出中的贡献者
+type diffContributor struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+}
+
+// diffReport diff 命令的完整结果
+type diffReport struct {
+	From         diffRef           `json:"from"`
+	To           diffRef           `json:"to"`
+	CompareURL   string            `json:"compareUrl,omitempty"`
+	TotalCommits int               `json:"totalCommits"`
+	Groups       []diffGroup       `json:"groups"`
+	Files        []diffFile        `json:"files"`
+	Additions    int               `json:"additions"`
+	Deletions    int               `json:"deletions"`
+	Contributors []diffContributor `json:"contributors"`
+}
+
+func runDiff(from, to, format string) error {
+	format = strings.ToLower(format)
+	if format != "text" && format != "markdown" && format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text, markdown, or json)", format)
+	}
+
+	// 1. 初始化
+	gitClient := git.NewGitClient(".")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	versionMgr, err := newVersionManager(cfg, cfg.RootTagPrefix())
+	if err != nil {
+		return err
+	}
+
+	// 2. 检查是否在 git 仓库中
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	// 3. 解析两端的版本
+	versionTags, err := loadVersionTags(gitClient, versionMgr)
+	if err != nil {
+		return err
+	}
+
+	fromRef, err := resolveDiffRef(gitClient, versionMgr, versionTags, from)
+	if err != nil {
+		return err
+	}
+	toRef, err := resolveDiffRef(gitClient, versionMgr, versionTags, to)
+	if err != nil {
+		return err
+	}
+
+	// 4. 收集 commits、文件变更和贡献者
+	commits, err := gitClient.GetCommitsBetween(fromRef.Commit, toRef.Commit)
+	if err != nil {
+		return err
+	}
+
+	files, err := gitClient.GetChangedFiles(fromRef.Commit, toRef.Commit)
+	if err != nil {
+		return err
+	}
+
+	report := buildDiffReport(fromRef, toRef, commits, files)
+
+	// 比较链接使用 tag 名，HEAD 则使用 commit hash
+	if repoURL, err := gitClient.GetRemoteURL(); err == nil {
+		report.CompareURL = compareURL(cfg, repoURL, compareTarget(fromRef), compareTarget(toRef))
+	}
+
+	// 5. 输出
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "markdown":
+		fmt.Print(renderDiffMarkdown(report, commits))
+	default:
+		printDiffText(report, commits)
+	}
+
+	return nil
+}
+
+// resolveDiffRef 将版本参数解析为 tag，使用与 ParseTags 相同的规则；HEAD 保持原样
+func resolveDiffRef(gitClient *git.GitClient, versionMgr *semver.VersionManager, versionTags []semver.VersionTag, arg string) (diffRef, error) {
+	if strings.EqualFold(arg, "HEAD") {
+		commit, err := gitClient.ResolveCommit("HEAD")
+		if err != nil {
+			return diffRef{}, err
+		}
+		return diffRef{Name: "HEAD", Commit: commit}, nil
+	}
+
+	want, err := versionMgr.ParseVersion(arg)
+	if err != nil {
+		return diffRef{}, fmt.Errorf("invalid version: %s", arg)
+	}
+
+	tag, ok := versionMgr.FindTag(versionTags, want)
+	if !ok {
+		return diffRef{}, fmt.Errorf("no tag found for version %s", arg)
+	}
+
+	commit, err := gitClient.ResolveCommit(tag.Name)
+	if err != nil {
+		return diffRef{}, err
+	}
+	return diffRef{Name: tag.Name, Version: versionMgr.FormatVersion(tag.Version), Commit: commit}, nil
+}
+
+// compareTarget 返回比较链接中使用的 ref
+func compareTarget(ref diffRef) string {
+	if ref.Version == "" {
+		return ref.Commit
+	}
+	return ref.Name
+}
+
+func buildDiffReport(from, to diffRef, commits []git.CommitInfo, files []git.FileChange) diffReport {
+	report := diffReport{
+		From:         from,
+		To:           to,
+		TotalCommits: len(commits),
+		Groups:       []diffGroup{},
+		Files:        []diffFile{},
+		Contributors: []diffContributor{},
+	}
+
+	for _, group := range changelog.GroupCommits(commits) {
+		g := diffGroup{Type: group.Type, Title: group.Title}
+		for _, entry := range group.Entries {
+			g.Commits = append(g.Commits, diffCommit{
+				Hash:     entry.Commit.Hash,
+				Scope:    entry.Scope,
+				Subject:  entry.Description,
+				Breaking: entry.Breaking,
+				Author:   entry.Commit.Author,
+				Date:     entry.Commit.Date,
+			})
+		}
+		report.Groups = append(report.Groups, g)
+	}
+
+	for _, file := range files {
+		report.Files = append(report.Files, diffFile(file))
+		report.Additions += file.Additions
+		report.Deletions += file.Deletions
+	}
+
+	for _, c := range changelog.Contributors(commits) {
+		report.Contributors = append(report.Contributors, diffContributor(c))
+	}
+
+	return report
+}
+
+func printDiffText(report diffReport, commits []git.CommitInfo) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("Changes %s → %s", report.From.Name, report.To.Name)))
+	fmt.Println()
+
+	if report.TotalCommits == 0 {
+		fmt.Println(ui.InfoStyle.Render("No commits between these versions"))
+	}
+
+	for _, group := range changelog.GroupCommits(commits) {
+		fmt.Println(ui.SelectedStyle.Render(group.Title))
+		for _, entry := range group.Entries {
+			fmt.Printf("  • %s\n", entry.Line())
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(ui.SelectedStyle.Render("Files"))
+	for _, file := range report.Files {
+		stat := fmt.Sprintf("+%d -%d", file.Additions, file.Deletions)
+		if file.Binary {
+			stat = "binary"
+		}
+		fmt.Printf("  %s %s\n", file.Path, ui.HelpStyle.Render(stat))
+	}
+	fmt.Println(ui.HelpStyle.Render(fmt.Sprintf("  %d files changed, %d insertions(+), %d deletions(-)",
+		len(report.Files), report.Additions, report.Deletions)))
+	fmt.Println()
+
+	fmt.Println(ui.SelectedStyle.Render("Contributors"))
+	for _, c := range report.Contributors {
+		fmt.Printf("  %s <%s> %s\n", c.Name, c.Email, ui.HelpStyle.Render(fmt.Sprintf("(%d)", c.Commits)))
+	}
+
+	if report.CompareURL != "" {
+		fmt.Println()
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("Compare: %s", report.CompareURL)))
+	}
+}
+
+func renderDiffMarkdown(report diffReport, commits []git.CommitInfo) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %s → %s\n\n", report.From.Name, report.To.Name))
+
+	if md := changelog.Markdown(changelog.GroupCommits(commits)); md != "" {
+		sb.WriteString(md)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("### Files\n\n")
+	sb.WriteString(fmt.Sprintf("%d files changed, %d insertions(+), %d deletions(-)\n\n",
+		len(report.Files), report.Additions, report.Deletions))
+	for _, file := range report.Files {
+		if file.Binary {
+			sb.WriteString(fmt.Sprintf("- `%s` (binary)\n", file.Path))
+		} else {
+			sb.WriteString(fmt.Sprintf("- `%s` (+%d -%d)\n", file.Path, file.Additions, file.Deletions))
+		}
+	}
+
+	sb.WriteString("\n### Contributors\n\n")
+	for _, c := range report.Contributors {
+		sb.WriteString(fmt.Sprintf("- %s (%d)\n", c.Name, c.Commits))
+	}
+
+	if report.CompareURL != "" {
+		sb.WriteString(fmt.Sprintf("\n**Full Changelog**: %s\n", report.CompareURL))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AkaraChen/tagger/internal/gomod"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/AkaraChen/tagger/internal/versionfile"
+)
+
+// checkModuleMajor 检查 dir 下 Go 模块的路径后缀是否与新的主版本匹配，dir 下没有 go.mod 时不做检查
+// 不匹配时询问是否改写模块路径及模块内的 import，返回需要加入 release commit 的修改；拒绝时返回错误
+func checkModuleMajor(repoRoot, dir string, major uint64) ([]versionfile.Change, error) {
+	m, err := gomod.Load(repoRoot, dir)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+
+	checkErr := gomod.CheckMajor(m.Path, major)
+	if checkErr == nil {
+		return nil, nil
+	}
+
+	newPath := gomod.MajorPath(m.Path, major)
+	fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("⚠ %s declares module %s, which Go tooling will not resolve as v%d", m.GoModPath(), m.Path, major)))
+
+	confirmed, err := ui.Confirm(fmt.Sprintf("Rewrite the module path to %s and update its imports in the release commit?", newPath), true)
+	if err != nil && err.Error() != "cancelled" {
+		return nil, fmt.Errorf("failed to confirm module path rewrite: %w", err)
+	}
+	if !confirmed {
+		return nil, checkErr
+	}
+
+	changes, err := gomod.RewritePath(repoRoot, m, newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Module path will be rewritten to %s (%d files)", newPath, len(changes))))
+	return changes, nil
+}
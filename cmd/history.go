@@ -5,14 +5,14 @@ import (
 	"sort"
 
 	"github.com/AkaraChen/tagger/internal/git"
-	"github.com/AkaraChen/tagger/internal/semver"
+	"github.com/AkaraChen/tagger/internal/gomod"
 	"github.com/AkaraChen/tagger/internal/ui"
-	semverlib "github.com/Masterminds/semver/v3"
 	"github.com/spf13/cobra"
 )
 
 var (
-	historyLimit int
+	historyLimit   int
+	historyPackage string
 )
 
 var historyCmd = &cobra.Command{
@@ -20,19 +20,28 @@ var historyCmd = &cobra.Command{
 	Short: "显示版本历史",
 	Long:  `显示仓库中的语义化版本标签历史`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runHistory(historyLimit)
+		return runHistory(historyLimit, historyPackage)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(historyCmd)
 	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 10, "显示的版本数量")
+	historyCmd.Flags().StringVarP(&historyPackage, "package", "p", "", "显示 packages 中配置的包的版本历史（monorepo）")
 }
 
-func runHistory(limit int) error {
+func runHistory(limit int, pkg string) error {
 	// 1. 初始化
 	gitClient := git.NewGitClient(".")
-	versionMgr := semver.NewVersionManager()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	versionMgr, dir, err := packageVersionManager(cfg, pkg)
+	if err != nil {
+		return err
+	}
 
 	// 2. 检查是否在 git 仓库中
 	isRepo, err := gitClient.IsGitRepository()
@@ -55,32 +64,21 @@ func runHistory(limit int) error {
 	}
 
 	// 4. 过滤符合 semver 格式的 tags
-	type versionInfo struct {
-		version *semverlib.Version
-		tagInfo git.TagInfo
-	}
-
-	var validVersions []versionInfo
-
-	for _, tagInfo := range tagInfos {
-		versions, _ := versionMgr.ParseTags([]string{tagInfo.Name})
-		if len(versions) > 0 {
-			validVersions = append(validVersions, versionInfo{
-				version: versions[0],
-				tagInfo: tagInfo,
-			})
-		}
+	validVersions, err := versionMgr.ParseTags(tagInfos)
+	if err != nil {
+		return fmt.Errorf("failed to parse tags: %w", err)
 	}
+	warnDuplicateTags(validVersions)
 
 	if len(validVersions) == 0 {
 		fmt.Println(ui.InfoStyle.Render("No semantic version tags found in this repository"))
-		fmt.Println(ui.HelpStyle.Render(fmt.Sprintf("Total tags: %d (none match vX.Y.Z format)", len(tagInfos))))
+		fmt.Println(ui.HelpStyle.Render(fmt.Sprintf("Total tags: %d (none match the %q prefix and %s versions)", len(tagInfos), versionMgr.Prefix, versionMgr.Scheme.Name())))
 		return nil
 	}
 
 	// 5. 按版本号排序（从新到旧）
 	sort.Slice(validVersions, func(i, j int) bool {
-		return validVersions[i].version.GreaterThan(validVersions[j].version)
+		return versionMgr.Scheme.Compare(validVersions[i].Version, validVersions[j].Version) > 0
 	})
 
 	// 6. 限制显示数量
@@ -88,21 +86,34 @@ func runHistory(limit int) error {
 		validVersions = validVersions[:limit]
 	}
 
+	// 版本线对应目录（仓库根目录或包的目录）的 go.mod 中被 retract 的版本会被标记
+	var module *gomod.Module
+	if repoRoot, err := gitClient.GetTopLevel(); err == nil {
+		module, _ = gomod.Load(repoRoot, dir)
+	}
+
 	// 7. 显示版本历史
 	fmt.Println(ui.TitleStyle.Render("Version History"))
 	fmt.Println()
 
-	for i, vInfo := range validVersions {
-		versionStr := versionMgr.FormatVersion(vInfo.version)
-		dateStr := vInfo.tagInfo.Date.Format("2006-01-02")
+	for i, tag := range validVersions {
+		dateStr := tag.Info.Date.Format("2006-01-02")
 
 		suffix := ""
 		if i == 0 {
 			suffix = ui.SuccessStyle.Render(" ← Latest")
 		}
+		if module != nil {
+			if rationale, ok := module.Retracted("v" + tag.Version.String()); ok {
+				suffix += ui.ErrorStyle.Render(" ✗ Retracted")
+				if rationale != "" {
+					suffix += ui.HelpStyle.Render(": " + rationale)
+				}
+			}
+		}
 
 		fmt.Printf("%s  (%s)%s\n",
-			ui.SelectedStyle.Render(versionStr),
+			ui.SelectedStyle.Render(tag.Name),
 			ui.HelpStyle.Render(dateStr),
 			suffix,
 		)
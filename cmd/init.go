@@ -4,33 +4,187 @@ import (
 	"fmt"
 
 	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/detect"
+	"github.com/AkaraChen/tagger/internal/git"
 	"github.com/AkaraChen/tagger/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	initYes   bool
+	initForce bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "创建配置文件",
-	Long:  `创建 tagger.config.json 配置文件，用于自定义 tagger 的行为`,
+	Long: `通过交互式向导创建 tagger.config.json 配置文件
+
+向导会根据仓库自动检测默认值：
+  - 托管平台：根据远程仓库 URL 判断
+  - tag 前缀：根据已有的 tags 推断
+  - monorepo 中的包：扫描包含 go.mod 或 package.json 的子目录
+  - 是否签名：根据 git 的 tag.gpgSign 配置
+
+使用 --yes 直接接受检测到的默认值，使用 --force 覆盖已有的配置文件`,
+	Args: cobra.NoArgs,
+	// 配置文件已存在等错误不是用法错误，不需要打印帮助
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println(ui.InfoStyle.Render("Creating tagger configuration file..."))
+		return runInit(initYes, initForce)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "接受检测到的默认值，不进行交互")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "覆盖已有的配置文件")
+}
 
-		err := config.CreateDefault()
+func runInit(yes, force bool) error {
+	// 尽早检查已有的配置文件，避免回答完所有问题后才报错
+	existing, err := config.FindProjectConfig()
+	if err != nil {
+		return err
+	}
+	if existing != "" && !force {
+		return fmt.Errorf("config file already exists: %s (use --force to overwrite)", existing)
+	}
+
+	path := config.ProjectConfigPath()
+	if existing != "" {
+		path = existing
+	}
+
+	defaults, packages, detected, err := detectInitDefaults()
+	if err != nil {
+		return err
+	}
+
+	answers := defaults
+	if !yes {
+		answers, err = ui.RunInitWizard(ui.InitWizardOptions{
+			Defaults: defaults,
+			Packages: packages,
+			Detected: detected,
+			Path:     path,
+		})
 		if err != nil {
-			return err
+			if err.Error() == "cancelled" {
+				fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
+				return nil
+			}
+			return fmt.Errorf("failed to run init wizard: %w", err)
 		}
+	} else {
+		for _, line := range detected {
+			fmt.Println(ui.HelpStyle.Render(line))
+		}
+	}
 
-		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Created %s", config.ConfigFileName)))
-		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("  Schema: %s", config.SchemaURL)))
-		fmt.Println()
-		fmt.Println(ui.HelpStyle.Render("You can now customize your configuration:"))
-		fmt.Println(ui.HelpStyle.Render("  - gitHostingProvider: GitHub or Other"))
-		fmt.Println(ui.HelpStyle.Render("  - github.openActionPage: true (Actions page) or false (homepage)"))
+	written, err := config.Create(initConfig(answers), force)
+	if err != nil {
+		return err
+	}
 
-		return nil
-	},
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Created %s", written)))
+	fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("  Schema: %s", config.SchemaURL)))
+	fmt.Println()
+	fmt.Println(ui.HelpStyle.Render("You can now customize your configuration:"))
+	fmt.Println(ui.HelpStyle.Render("  - gitHostingProvider: GitHub or Other"))
+	fmt.Println(ui.HelpStyle.Render("  - github.openActionPage: true (Actions page) or false (homepage)"))
+	fmt.Println(ui.HelpStyle.Render("  - tagPrefix, sign, push (ask/always/never), changelog"))
+	fmt.Println(ui.HelpStyle.Render("  - packages: tag monorepo packages independently with --package <name>"))
+	fmt.Println(ui.HelpStyle.Render("  - messageTemplate: Go text/template for the tag message (empty render = lightweight tag)"))
+
+	return nil
 }
 
-func init() {
-	rootCmd.AddCommand(initCmd)
+// detectInitDefaults 根据仓库检测向导的默认值，返回默认答案、扫描到的包和检测结果说明
+func detectInitDefaults() (ui.InitAnswers, []config.PackageConfig, []string, error) {
+	gitClient := git.NewGitClient(".")
+
+	defaults := ui.InitAnswers{
+		Provider:       config.GitHub,
+		OpenActionPage: true,
+		TagPrefix:      config.DefaultTagPrefix,
+		Push:           config.PushAsk,
+		Changelog:      true,
+	}
+	var detected []string
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return defaults, nil, nil, fmt.Errorf("failed to check git repository: %w", err)
+	}
+
+	if isRepo {
+		hasRemote, err := gitClient.HasRemote()
+		if err != nil {
+			return defaults, nil, nil, fmt.Errorf("failed to check remote: %w", err)
+		}
+		if hasRemote {
+			repoURL, err := gitClient.GetRemoteURL()
+			if err != nil {
+				return defaults, nil, nil, err
+			}
+			defaults.Provider = detect.Provider(repoURL)
+			detected = append(detected, fmt.Sprintf("Remote:   %s (%s)", repoURL, defaults.Provider))
+		}
+
+		tags, err := gitClient.GetAllTags()
+		if err != nil {
+			return defaults, nil, nil, fmt.Errorf("failed to get tags: %w", err)
+		}
+		defaults.TagPrefix = detect.TagPrefix(tags)
+		detected = append(detected, fmt.Sprintf("Tags:     %d (prefix %q)", len(tags), defaults.TagPrefix))
+
+		gpgSign, err := gitClient.GetConfig("tag.gpgSign")
+		if err != nil {
+			return defaults, nil, nil, err
+		}
+		defaults.Sign = gpgSign == "true"
+	}
+
+	packages, err := detect.Packages(config.ProjectRoot())
+	if err != nil {
+		return defaults, nil, nil, fmt.Errorf("failed to scan packages: %w", err)
+	}
+	if len(packages) > 0 {
+		defaults.Packages = packages
+		detected = append(detected, fmt.Sprintf("Packages: %d found", len(packages)))
+	}
+
+	return defaults, packages, detected, nil
+}
+
+// initConfig 将向导的答案转换为配置，与默认行为相同的选项不写入文件
+func initConfig(a ui.InitAnswers) *config.Config {
+	cfg := &config.Config{
+		Schema:             config.SchemaURL,
+		GitHostingProvider: a.Provider,
+		Sign:               a.Sign,
+		Packages:           a.Packages,
+	}
+
+	if a.Provider == config.GitHub {
+		openActionPage := a.OpenActionPage
+		cfg.GitHub = &config.GitHubConfig{OpenActionPage: &openActionPage}
+	}
+	if a.TagPrefix != config.DefaultTagPrefix {
+		prefix := a.TagPrefix
+		cfg.TagPrefix = &prefix
+	}
+	if a.Push != config.PushAsk {
+		cfg.Push = a.Push
+	}
+
+	// 选择包含变更日志时，使用带有变更日志的 message 模板
+	changelog := a.Changelog
+	cfg.Changelog = &changelog
+	if a.Changelog {
+		cfg.MessageTemplate = config.ExampleMessageTemplate
+	}
+
+	return cfg
 }
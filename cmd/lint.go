@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/lint"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat string
+	lintBranch string
+	lintIgnore []string
+)
+
+var lintCmd = &cobra.Command{
+	Use:     "lint",
+	Aliases: []string{"doctor"},
+	Short:   "检查版本 tag 的问题",
+	Long: `检查仓库根目录和 packages 中每个包的版本 tag：跳过的版本、解析为同一个版本的多个 tag、
+不符合版本格式的 tag、lightweight 和 annotated tag 混用、不在默认分支上的 tag，以及版本更高但创建时间更早的 tag。
+发现问题时以非零状态码退出，适用于 CI`,
+	Args: cobra.NoArgs,
+	// 发现问题不是用法错误，不需要打印帮助
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLint(lintFormat, lintBranch, lintIgnore)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringVarP(&lintFormat, "format", "f", "text", "输出格式：text 或 json")
+	lintCmd.Flags().StringVar(&lintBranch, "branch", "", "检查 tag 是否在该分支上（默认使用远程仓库的默认分支、main 或 master）")
+	lintCmd.Flags().StringSliceVar(&lintIgnore, "ignore", nil, "忽略的检查：skipped、duplicate、non-conforming、tag-type、unreachable、date-order")
+}
+
+func runLint(format, branch string, ignore []string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text or json)", format)
+	}
+
+	var ignored []lint.Kind
+	for _, name := range ignore {
+		kind, err := lint.ParseKind(name)
+		if err != nil {
+			return err
+		}
+		ignored = append(ignored, kind)
+	}
+
+	gitClient := git.NewGitClient(".")
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// 仓库根目录和每个包各是一条版本线
+	var lines []lint.Line
+	versionMgr, err := newVersionManager(cfg, cfg.RootTagPrefix())
+	if err != nil {
+		return err
+	}
+	lines = append(lines, lint.Line{Manager: versionMgr})
+	if cfg != nil {
+		for _, pkg := range cfg.Packages {
+			versionMgr, err := newVersionManager(cfg, pkg.Prefix())
+			if err != nil {
+				return err
+			}
+			lines = append(lines, lint.Line{Name: pkg.Name, Manager: versionMgr})
+		}
+	}
+
+	tagInfos, err := gitClient.GetTagsWithDates()
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	if branch == "" {
+		branch, err = gitClient.GetDefaultBranch()
+		if err != nil {
+			return err
+		}
+	}
+	opts := lint.Options{DefaultBranch: branch}
+	// 分支不存在（例如还没有 commit）时不检查可达性
+	if _, err := gitClient.ResolveCommit(branch); err == nil {
+		opts.Reachable = func(commit string) (bool, error) {
+			return gitClient.IsAncestor(commit, branch)
+		}
+	}
+
+	report, err := lint.Check(tagInfos, lines, opts)
+	if err != nil {
+		return err
+	}
+	report = report.Filter(ignored)
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printLintText(report)
+	}
+
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("found %d problem(s) in %d tags", len(report.Issues), report.Tags)
+	}
+	return nil
+}
+
+func printLintText(report lint.Report) {
+	if len(report.Issues) == 0 {
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ No problems found in %d tags", report.Tags)))
+		return
+	}
+
+	var current lint.Kind
+	for _, issue := range report.Issues {
+		if issue.Kind != current {
+			if current != "" {
+				fmt.Println()
+			}
+			current = issue.Kind
+			fmt.Println(ui.SelectedStyle.Render(issue.Kind.Title()))
+		}
+
+		tag := issue.Tag
+		if issue.Line != "" {
+			tag = fmt.Sprintf("%s (%s)", issue.Tag, issue.Line)
+		}
+		fmt.Printf("  %s %s %s\n", ui.ErrorStyle.Render("✗"), tag, ui.HelpStyle.Render(issue.Message))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/migrate"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFrom      string
+	migrateTo        string
+	migrateDeleteOld bool
+	migratePush      bool
+	migrateDryRun    bool
+	migrateYes       bool
+	migrateFormat    string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "迁移 tag 的命名格式",
+	Long: `为符合 --from-format 的 tag 创建 --to-format 格式的新 tag，新 tag 指向相同的 commit，
+annotated tag 保留原来的 tagger、时间和 message。可以推送新的 tag，并在本地和远程仓库删除旧的 tag。
+格式中的 {version} 表示版本号，例如 v{version}、release-{version} 或 api/v{version}。
+计划根据本地和远程仓库的当前状态生成，推送中断后使用相同的参数重新执行即可继续`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runMigrate(migrateFrom, migrateTo, migrateFormat, migrateDeleteOld, migratePush, migrateDryRun, migrateYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVar(&migrateFrom, "from-format", "", "旧的 tag 格式，例如 release-{version}")
+	migrateCmd.Flags().StringVar(&migrateTo, "to-format", "", "新的 tag 格式，例如 v{version}")
+	migrateCmd.Flags().BoolVar(&migrateDeleteOld, "delete-old", false, "删除旧的 tag（与 --push 一起使用时也删除远程仓库中的旧 tag）")
+	migrateCmd.Flags().BoolVar(&migratePush, "push", false, "推送新的 tag 到远程")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "只输出迁移计划，不做任何修改")
+	migrateCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, "不确认，直接执行")
+	migrateCmd.Flags().StringVarP(&migrateFormat, "format", "f", "text", "输出格式：text 或 json")
+	migrateCmd.MarkFlagRequired("from-format")
+	migrateCmd.MarkFlagRequired("to-format")
+}
+
+func runMigrate(fromFormat, toFormat, format string, deleteOld, push, dryRun, yes bool) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text or json)", format)
+	}
+	// JSON 输出用于脚本，无法交互确认
+	if format == "json" && !dryRun && !yes {
+		return fmt.Errorf("--yes is required with --format json unless --dry-run is set")
+	}
+
+	from, err := migrate.ParseFormat(fromFormat)
+	if err != nil {
+		return err
+	}
+	to, err := migrate.ParseFormat(toFormat)
+	if err != nil {
+		return err
+	}
+
+	gitClient := git.NewGitClient(".")
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	// 只使用配置中的版本方案解析版本号，前缀由格式决定
+	versionMgr, err := newVersionManager(cfg, "")
+	if err != nil {
+		return err
+	}
+
+	tagInfos, err := gitClient.GetTagsWithDates()
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	opts := migrate.Options{Push: push, DeleteOld: deleteOld}
+	if push {
+		hasRemote, err := gitClient.HasRemote()
+		if err != nil {
+			return fmt.Errorf("failed to check remote: %w", err)
+		}
+		if !hasRemote {
+			return fmt.Errorf("--push requires a remote repository")
+		}
+		opts.RemoteTags, err = gitClient.GetRemoteTags()
+		if err != nil {
+			return err
+		}
+	}
+
+	items, err := migrate.Plan(tagInfos, from, to, versionMgr.Scheme, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		if format == "json" {
+			return printMigrateJSON(items)
+		}
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("No tags match %s", from)))
+		return nil
+	}
+
+	pending := 0
+	for _, item := range items {
+		if item.Pending() {
+			pending++
+		}
+	}
+
+	if format == "text" {
+		printMigratePlan(from, to, items)
+	}
+	if dryRun || pending == 0 {
+		if format == "json" {
+			return printMigrateJSON(items)
+		}
+		if pending == 0 {
+			fmt.Println(ui.SuccessStyle.Render("✓ All tags have already been migrated"))
+		} else {
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: %d tag(s) would be migrated", pending)))
+		}
+		return nil
+	}
+
+	if !yes {
+		confirmed, err := ui.Confirm(fmt.Sprintf("Migrate %d tag(s)?", pending), false)
+		if err != nil && err.Error() != "cancelled" {
+			return fmt.Errorf("failed to confirm: %w", err)
+		}
+		if !confirmed {
+			fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
+			return nil
+		}
+	}
+
+	verbose := format == "text"
+	migrateErr := executeMigration(gitClient, items, verbose)
+
+	if format == "json" {
+		if err := printMigrateJSON(items); err != nil {
+			return err
+		}
+	}
+	if migrateErr != nil {
+		return fmt.Errorf("%w; run the same command again to resume", migrateErr)
+	}
+
+	if verbose {
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Migrated %d tag(s) from %s to %s", pending, from, to)))
+		// 迁移到配置的前缀以外的格式后，tagger 需要新的前缀才能识别这些 tag
+		if to.Suffix == "" && cfg.RootTagPrefix() == from.Prefix && from.Suffix == "" {
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("  Update the config to use the new prefix: tagger config set tagPrefix %q", to.Prefix)))
+		}
+	}
+	return nil
+}
+
+// executeMigration 依次创建新 tag、推送新 tag、删除远程和本地的旧 tag
+// 任何一步失败都会停止，之后的步骤（尤其是删除旧 tag）不会执行，重新运行时从中断的位置继续
+func executeMigration(gitClient *git.GitClient, items []migrate.Item, verbose bool) error {
+	phases := []struct {
+		status func(*migrate.Item) *migrate.Status
+		run    func(item *migrate.Item) error
+		done   string
+	}{
+		{
+			status: func(item *migrate.Item) *migrate.Status { return &item.Create },
+			run: func(item *migrate.Item) error {
+				signed, err := gitClient.CopyTag(item.From, item.To)
+				if err == nil && signed && verbose {
+					fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("⚠ Warning: the signature of %s cannot be kept; %s is unsigned", item.From, item.To)))
+				}
+				return err
+			},
+			done: "Created %[2]s from %[1]s",
+		},
+		{
+			status: func(item *migrate.Item) *migrate.Status { return &item.Push },
+			run:    func(item *migrate.Item) error { return gitClient.PushTag(item.To) },
+			done:   "Pushed %[2]s",
+		},
+		{
+			status: func(item *migrate.Item) *migrate.Status { return &item.DeleteRemote },
+			run:    func(item *migrate.Item) error { return gitClient.DeleteRemoteTag(item.From) },
+			done:   "Deleted %[1]s from remote",
+		},
+		{
+			status: func(item *migrate.Item) *migrate.Status { return &item.DeleteLocal },
+			run:    func(item *migrate.Item) error { return gitClient.DeleteTag(item.From) },
+			done:   "Deleted local tag %[1]s",
+		},
+	}
+
+	for _, phase := range phases {
+		for i := range items {
+			item := &items[i]
+			status := phase.status(item)
+			if *status != migrate.Pending {
+				continue
+			}
+
+			if err := phase.run(item); err != nil {
+				*status = migrate.Failed
+				item.Error = strings.TrimSpace(err.Error())
+				if verbose {
+					fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("✗ %v", err)))
+				}
+				return err
+			}
+			*status = migrate.Done
+			if verbose {
+				fmt.Println(ui.SuccessStyle.Render("✓ ") + fmt.Sprintf(phase.done, item.From, item.To))
+			}
+		}
+	}
+	return nil
+}
+
+func printMigratePlan(from, to migrate.Format, items []migrate.Item) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("Migration plan: %s → %s", from, to)))
+	fmt.Println()
+
+	for _, item := range items {
+		kind := "lightweight"
+		if item.Annotated {
+			kind = "annotated"
+		}
+
+		var steps []string
+		for _, step := range []struct {
+			name   string
+			status migrate.Status
+		}{
+			{"create", item.Create},
+			{"push", item.Push},
+			{"delete remote", item.DeleteRemote},
+			{"delete local", item.DeleteLocal},
+		} {
+			switch step.status {
+			case migrate.Pending:
+				steps = append(steps, step.name)
+			case migrate.Done:
+				steps = append(steps, step.name+" ✓")
+			}
+		}
+
+		fmt.Printf("  %s → %s  %s  %s\n",
+			item.From,
+			ui.SelectedStyle.Render(item.To),
+			ui.HelpStyle.Render(fmt.Sprintf("(%s, %s)", kind, short(item.Commit))),
+			ui.HelpStyle.Render(strings.Join(steps, ", ")),
+		)
+	}
+	fmt.Println()
+}
+
+func printMigrateJSON(items []migrate.Item) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(items)
+}
+
+// short 返回 commit hash 的缩写
+func short(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/prune"
+	"github.com/AkaraChen/tagger/internal/semver"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneOlderThan  int
+	pruneSuperseded bool
+	pruneKeep       int
+	prunePackage    string
+	pruneRemote     bool
+	pruneDryRun     bool
+	pruneYes        bool
+	pruneFormat     string
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "删除旧的预发布 tag",
+	Long: `删除创建时间早于 --older-than 天，或者已经有更高的正式版本（--superseded）的预发布 tag，
+例如 v1.3.0-rc.1 和 v1.3.0-nightly.20240101。每个版本线中每个预发布通道（rc、nightly 等）保留最新的 --keep 个，
+版本线中最新的版本始终保留。删除前列出要删除的 tag 并确认，--remote 同时删除远程仓库中的这些 tag`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runPrune(pruneFormat, prunePackage, pruneOlderThan, pruneKeep, pruneSuperseded, pruneRemote, pruneDryRun, pruneYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+	pruneCmd.Flags().IntVar(&pruneOlderThan, "older-than", 0, "删除创建时间早于该天数的预发布 tag")
+	pruneCmd.Flags().BoolVar(&pruneSuperseded, "superseded", false, "删除已经有更高的正式版本的预发布 tag")
+	pruneCmd.Flags().IntVar(&pruneKeep, "keep", 0, "每个版本线的每个预发布通道保留最新的 tag 数量")
+	pruneCmd.Flags().StringVarP(&prunePackage, "package", "p", "", "只处理 packages 中配置的包（monorepo，默认处理所有版本线）")
+	pruneCmd.Flags().BoolVar(&pruneRemote, "remote", false, "同时删除远程仓库中的 tag")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "只列出要删除的 tag，不做任何修改")
+	pruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "不确认，直接删除")
+	pruneCmd.Flags().StringVarP(&pruneFormat, "format", "f", "text", "输出格式：text 或 json")
+}
+
+// pruneItem JSON 输出中的一个要删除的 tag
+type pruneItem struct {
+	// Line 版本线的名称，仓库根目录为空字符串
+	Line       string    `json:"line,omitempty"`
+	Tag        string    `json:"tag"`
+	Duplicates []string  `json:"duplicates,omitempty"`
+	Date       time.Time `json:"date"`
+	Reasons    []string  `json:"reasons"`
+	// Remote tag 是否存在于远程仓库中，只在使用 --remote 时有意义
+	Remote bool `json:"remote,omitempty"`
+}
+
+func runPrune(format, pkg string, olderThan, keep int, superseded, remote, dryRun, yes bool) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text or json)", format)
+	}
+	if olderThan <= 0 && !superseded {
+		return fmt.Errorf("specify --older-than and/or --superseded to choose the tags to delete")
+	}
+	if olderThan < 0 || keep < 0 {
+		return fmt.Errorf("--older-than and --keep must not be negative")
+	}
+	// JSON 输出用于脚本，无法交互确认
+	if format == "json" && !dryRun && !yes {
+		return fmt.Errorf("--yes is required with --format json unless --dry-run is set")
+	}
+
+	gitClient := git.NewGitClient(".")
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// 仓库根目录和每个包各是一条版本线，--package 只选择其中一条
+	type line struct {
+		name       string
+		versionMgr *semver.VersionManager
+	}
+	var lines []line
+	if pkg != "" {
+		versionMgr, _, err := packageVersionManager(cfg, pkg)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line{name: pkg, versionMgr: versionMgr})
+	} else {
+		versionMgr, err := newVersionManager(cfg, cfg.RootTagPrefix())
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line{versionMgr: versionMgr})
+		if cfg != nil {
+			for _, pkg := range cfg.Packages {
+				versionMgr, err := newVersionManager(cfg, pkg.Prefix())
+				if err != nil {
+					return err
+				}
+				lines = append(lines, line{name: pkg.Name, versionMgr: versionMgr})
+			}
+		}
+	}
+
+	tagInfos, err := gitClient.GetTagsWithDates()
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var remoteTags map[string]string
+	if remote {
+		hasRemote, err := gitClient.HasRemote()
+		if err != nil {
+			return fmt.Errorf("failed to check remote: %w", err)
+		}
+		if !hasRemote {
+			return fmt.Errorf("--remote requires a remote repository")
+		}
+		remoteTags, err = gitClient.GetRemoteTags()
+		if err != nil {
+			return err
+		}
+	}
+
+	opts := prune.Options{
+		OlderThan:  time.Duration(olderThan) * 24 * time.Hour,
+		Superseded: superseded,
+		Keep:       keep,
+		Now:        time.Now(),
+	}
+
+	items := []pruneItem{}
+	var localNames, remoteNames []string
+	for _, l := range lines {
+		versionTags, err := l.versionMgr.ParseTags(tagInfos)
+		if err != nil {
+			return err
+		}
+		for _, candidate := range prune.Select(l.versionMgr, versionTags, opts) {
+			item := pruneItem{
+				Line:       l.name,
+				Tag:        candidate.Tag.Name,
+				Duplicates: candidate.Tag.Duplicates,
+				Date:       candidate.Tag.Info.Date,
+				Reasons:    candidate.Reasons,
+			}
+			for _, name := range candidate.Names() {
+				localNames = append(localNames, name)
+				if _, exists := remoteTags[name]; exists {
+					remoteNames = append(remoteNames, name)
+					item.Remote = true
+				}
+			}
+			items = append(items, item)
+		}
+	}
+
+	if format == "text" {
+		if len(items) == 0 {
+			fmt.Println(ui.SuccessStyle.Render("✓ No prerelease tags to prune"))
+			return nil
+		}
+		printPrunePlan(items, remote)
+	}
+	if dryRun || len(items) == 0 {
+		if format == "json" {
+			return printPruneJSON(items)
+		}
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: %d tag(s) would be deleted", len(localNames))))
+		return nil
+	}
+
+	if !yes {
+		prompt := fmt.Sprintf("Delete %d local tag(s)?", len(localNames))
+		if remote {
+			prompt = fmt.Sprintf("Delete %d local and %d remote tag(s)?", len(localNames), len(remoteNames))
+		}
+		confirmed, err := ui.Confirm(prompt, false)
+		if err != nil && err.Error() != "cancelled" {
+			return fmt.Errorf("failed to confirm: %w", err)
+		}
+		if !confirmed {
+			fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
+			return nil
+		}
+	}
+
+	// 先删除远程仓库中的 tag，失败时本地的 tag 仍然保留，重新执行即可继续
+	if len(remoteNames) > 0 {
+		if err := gitClient.DeleteRemoteTag(remoteNames...); err != nil {
+			return err
+		}
+		if format == "text" {
+			fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Deleted %d tag(s) from remote", len(remoteNames))))
+		}
+	}
+	if err := gitClient.DeleteTag(localNames...); err != nil {
+		return err
+	}
+
+	if format == "json" {
+		return printPruneJSON(items)
+	}
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Deleted %d local tag(s)", len(localNames))))
+	return nil
+}
+
+func printPrunePlan(items []pruneItem, remote bool) {
+	fmt.Println(ui.TitleStyle.Render("Prerelease tags to delete"))
+	fmt.Println()
+
+	current := ""
+	for i, item := range items {
+		if i == 0 || item.Line != current {
+			current = item.Line
+			name := current
+			if name == "" {
+				name = "(root)"
+			}
+			fmt.Println(ui.SelectedStyle.Render(name))
+		}
+
+		var details []string
+		if !item.Date.IsZero() {
+			details = append(details, item.Date.Format("2006-01-02"))
+		}
+		details = append(details, item.Reasons...)
+		if remote && !item.Remote {
+			details = append(details, "local only")
+		}
+		fmt.Printf("  %s  %s\n", item.Tag, ui.HelpStyle.Render(strings.Join(details, ", ")))
+		for _, name := range item.Duplicates {
+			fmt.Printf("  %s  %s\n", name, ui.HelpStyle.Render("same version as "+item.Tag))
+		}
+	}
+	fmt.Println()
+}
+
+func printPruneJSON(items []pruneItem) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(items)
+}
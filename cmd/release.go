@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/AkaraChen/tagger/internal/versionfile"
+)
+
+// releaseCommitMessage 同步版本号的 commit message
+func releaseCommitMessage(tag string) string {
+	return fmt.Sprintf("chore(release): %s", tag)
+}
+
+// prepareRelease 计算 versionFiles 中每个文件写入新版本后的内容以及生成的 Go 版本文件，只返回有变化的文件
+// 所有文件都在写入前计算完毕，任何一个文件出错时不会留下部分修改
+func prepareRelease(cfg *config.Config, repoRoot string, meta versionfile.Metadata) ([]versionfile.Change, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var changes []versionfile.Change
+	for _, f := range cfg.VersionFiles {
+		change, err := versionfile.Update(repoRoot, f, meta.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update version file: %w", err)
+		}
+		if change.Changed() {
+			changes = append(changes, change)
+		}
+	}
+
+	if cfg.GoVersionFile != nil {
+		change, err := versionfile.GenerateGo(repoRoot, *cfg.GoVersionFile, meta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Go version file: %w", err)
+		}
+		if change.Changed() {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// releaseMetadata 返回写入生成的 Go 版本文件的发布信息
+func releaseMetadata(version, previousVersion, commit string) versionfile.Metadata {
+	return versionfile.Metadata{
+		Version:         version,
+		Commit:          commit,
+		Date:            time.Now().UTC().Format(time.RFC3339),
+		PreviousVersion: previousVersion,
+	}
+}
+
+// commitRelease 写入修改并提交为 release commit，dry run 时只输出 diff
+func commitRelease(gitClient *git.GitClient, repoRoot, commitMessage string, changes []versionfile.Change, dryRun bool) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle.Render("🔍 Dry run: Would update version files:"))
+		for _, change := range changes {
+			fmt.Print(colorDiff(change.Diff()))
+		}
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would commit %q", commitMessage)))
+		return nil
+	}
+
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if err := versionfile.Write(repoRoot, change); err != nil {
+			return err
+		}
+		paths = append(paths, change.Path)
+	}
+
+	if err := gitClient.Commit(commitMessage, paths...); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Committed %s (%s)", commitMessage, strings.Join(paths, ", "))))
+	return nil
+}
+
+// colorDiff 为 diff 中增删的行着色
+func colorDiff(diff string) string {
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case text == "":
+			sb.WriteString(line)
+			continue
+		case strings.HasPrefix(text, "---"), strings.HasPrefix(text, "+++"), strings.HasPrefix(text, "@@"):
+			text = ui.HelpStyle.Render(text)
+		case strings.HasPrefix(text, "-"):
+			text = ui.ErrorStyle.Render(text)
+		case strings.HasPrefix(text, "+"):
+			text = ui.SuccessStyle.Render(text)
+		}
+		sb.WriteString(text + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/gomod"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	retractReason  string
+	retractPackage string
+	retractDryRun  bool
+	retractPush    bool
+	retractNoPush  bool
+)
+
+var retractCmd = &cobra.Command{
+	Use:   "retract <version> [<to-version>]",
+	Short: "撤回有问题的 Go 模块版本",
+	Long: `在 go.mod 中加入 retract 指令撤回一个版本（或 version 到 to-version 的范围），
+提交修改并创建下一个补丁版本的 tag，使撤回生效`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		high := args[0]
+		if len(args) == 2 {
+			high = args[1]
+		}
+		return runRetract(args[0], high, retractReason, retractPackage, retractDryRun, retractPush, retractNoPush)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(retractCmd)
+	retractCmd.Flags().StringVarP(&retractReason, "reason", "r", "", "撤回的原因，写入 go.mod 的注释和 tag message")
+	retractCmd.Flags().StringVarP(&retractPackage, "package", "p", "", "撤回 packages 中配置的包的版本（monorepo）")
+	retractCmd.Flags().BoolVar(&retractDryRun, "dry-run", false, "模拟运行")
+	retractCmd.Flags().BoolVar(&retractPush, "push", false, "自动推送到远程")
+	retractCmd.Flags().BoolVar(&retractNoPush, "no-push", false, "不推送到远程")
+}
+
+func runRetract(lowInput, highInput, reason, pkg string, dryRun, autoPush, noPush bool) error {
+	gitClient := git.NewGitClient(".")
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	versionMgr, moduleDir, err := packageVersionManager(cfg, pkg)
+	if err != nil {
+		return err
+	}
+	if !versionMgr.IsSemVer() {
+		return fmt.Errorf("retract requires the semver version scheme")
+	}
+
+	repoRoot, err := gitClient.GetTopLevel()
+	if err != nil {
+		return err
+	}
+
+	module, err := gomod.Load(repoRoot, moduleDir)
+	if err != nil {
+		return err
+	}
+	if module == nil {
+		return fmt.Errorf("no go.mod found in %s", displayDir(moduleDir))
+	}
+
+	low, err := versionMgr.ParseInput(lowInput)
+	if err != nil {
+		return fmt.Errorf("invalid semantic version: %s", lowInput)
+	}
+	high, err := versionMgr.ParseInput(highInput)
+	if err != nil {
+		return fmt.Errorf("invalid semantic version: %s", highInput)
+	}
+
+	versionTags, err := loadVersionTags(gitClient, versionMgr)
+	if err != nil {
+		return err
+	}
+
+	// 只能撤回已经发布的版本，撤回需要一个更新的版本才能被 Go 工具链看到
+	latest := versionMgr.GetLatestVersion(versionTags)
+	current := latest.Version
+	if !latest.Tagged() {
+		return fmt.Errorf("cannot retract %s: no version has been tagged yet", versionMgr.FormatVersion(high))
+	}
+	if high.GreaterThan(current) {
+		return fmt.Errorf("cannot retract %s: it is newer than the latest version %s",
+			versionMgr.FormatVersion(high), latest.Name)
+	}
+	if _, ok := versionMgr.FindTag(versionTags, low); low.Equal(high) && !ok {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("⚠ Warning: tag %s does not exist", versionMgr.FormatVersion(low))))
+	}
+
+	newVersion := versionMgr.BumpPatch(current)
+	newTag := versionMgr.FormatVersion(newVersion)
+	exists, err := gitClient.TagExists(newTag)
+	if err != nil {
+		return fmt.Errorf("failed to check tag existence: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("tag %s already exists", newTag)
+	}
+
+	// go.mod 中的版本始终以 v 开头，与 tag 前缀无关
+	lowVersion, highVersion := "v"+low.String(), "v"+high.String()
+	retractChange, err := gomod.Retract(module, lowVersion, highVersion, reason)
+	if err != nil {
+		return err
+	}
+
+	head, err := gitClient.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+	changes, err := prepareRelease(cfg, repoRoot, releaseMetadata(newVersion.String(), current.String(), head))
+	if err != nil {
+		return err
+	}
+	changes = append(changes, retractChange)
+
+	retracted := lowVersion
+	if lowVersion != highVersion {
+		retracted = fmt.Sprintf("[%s, %s]", lowVersion, highVersion)
+	}
+	commitMessage := fmt.Sprintf("%s, retract %s", releaseCommitMessage(newTag), retracted)
+	if err := commitRelease(gitClient, repoRoot, commitMessage, changes, dryRun); err != nil {
+		return err
+	}
+
+	tagMessage := fmt.Sprintf("Retract %s", retracted)
+	if reason != "" {
+		tagMessage += ": " + reason
+	}
+	if err := createTag(gitClient, newTag, tagMessage, cfg != nil && cfg.Sign, dryRun); err != nil {
+		return err
+	}
+
+	return pushRetract(cfg, gitClient, newTag, autoPush, noPush, dryRun)
+}
+
+// pushRetract 推送撤回的 commit 和新的 tag，推送方式与 tag 命令一致
+func pushRetract(cfg *config.Config, gitClient *git.GitClient, tag string, autoPush, noPush, dryRun bool) error {
+	hasRemote, err := gitClient.HasRemote()
+	if err != nil {
+		return fmt.Errorf("failed to check remote: %w", err)
+	}
+	if !hasRemote {
+		fmt.Println(ui.InfoStyle.Render("No remote repository configured, skipping push"))
+		return nil
+	}
+
+	pushMode := cfg.PushPolicy()
+	switch {
+	case autoPush:
+		pushMode = config.PushAlways
+	case noPush:
+		pushMode = config.PushNever
+	}
+
+	push := pushMode == config.PushAlways
+	if pushMode == config.PushAsk {
+		push, err = ui.Confirm(fmt.Sprintf("Push the retraction and tag %s to remote?", tag), true)
+		if err != nil && err.Error() != "cancelled" {
+			return fmt.Errorf("failed to confirm push: %w", err)
+		}
+	}
+	if !push {
+		return nil
+	}
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would push the release commit and tag %s to remote", tag)))
+		return nil
+	}
+
+	if err := gitClient.PushBranch(); err != nil {
+		fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("✗ Failed to push release commit: %v", err)))
+		fmt.Println(ui.InfoStyle.Render("  You can manually push with: git push origin HEAD"))
+		return nil
+	}
+	if err := gitClient.PushTag(tag); err != nil {
+		fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("✗ Failed to push tag: %v", err)))
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("  You can manually push with: git push origin %s", tag)))
+		return nil
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Tag %s pushed to remote successfully!", tag)))
+	return nil
+}
+
+// displayDir 返回用于输出的目录名，仓库根目录显示为 the repository root
+func displayDir(dir string) string {
+	if dir == "" {
+		return "the repository root"
+	}
+	return dir
+}
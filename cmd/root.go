@@ -4,16 +4,25 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/semver"
 	"github.com/AkaraChen/tagger/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
+	// 全局参数
+	configPath string
+
 	// Tag 命令参数
-	tagMessage string
-	autoPush   bool
-	noPush     bool
-	dryRun     bool
+	tagMessage  string
+	autoPush    bool
+	noPush      bool
+	dryRun      bool
+	force       bool
+	tagPackage  string
+	tagPreid    string
+	tagMetadata string
 )
 
 // rootCmd 代表 tag 命令（默认命令）
@@ -21,11 +30,54 @@ var rootCmd = &cobra.Command{
 	Use:   "tagger",
 	Short: "Git 语义化版本标签管理工具",
 	Long:  `Tagger 是一个用于创建和管理 Git 语义化版本标签的工具`,
+	// 错误由 Execute 统一输出
+	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return RunTag(tagMessage, autoPush, noPush, dryRun)
+		// 参数解析完成后的错误（例如钩子失败）不是用法错误，不需要打印帮助
+		cmd.SilenceUsage = true
+		return RunTag(tagMessage, autoPush, noPush, dryRun, force, tagPackage, tagPreid, tagMetadata)
 	},
 }
 
+// loadConfig 加载合并后的配置，使用 --config 指定的文件代替项目配置
+func loadConfig() (*config.Config, error) {
+	return config.Load(configPath)
+}
+
+// newVersionManager 创建使用 prefix 和配置中版本方案的 VersionManager
+func newVersionManager(cfg *config.Config, prefix string) (*semver.VersionManager, error) {
+	versionMgr := semver.NewPrefixedVersionManager(prefix)
+	versionMgr.InitialDevelopment = cfg != nil && cfg.InitialDevelopment
+	if cfg.Scheme() == config.SchemeCalVer {
+		format := cfg.CalVerFormat
+		if format == "" {
+			format = semver.DefaultCalVerFormat
+		}
+		scheme, err := semver.NewCalVer(format)
+		if err != nil {
+			return nil, err
+		}
+		versionMgr.Scheme = scheme
+	}
+	return versionMgr, nil
+}
+
+// packageVersionManager 返回仓库根目录或 packages 中某个包的 VersionManager 以及包的目录
+// pkg 为空时使用仓库根目录，目录为空字符串
+func packageVersionManager(cfg *config.Config, pkg string) (*semver.VersionManager, string, error) {
+	if pkg == "" {
+		versionMgr, err := newVersionManager(cfg, cfg.RootTagPrefix())
+		return versionMgr, "", err
+	}
+
+	pkgConfig, err := cfg.FindPackage(pkg)
+	if err != nil {
+		return nil, "", err
+	}
+	versionMgr, err := newVersionManager(cfg, pkgConfig.Prefix())
+	return versionMgr, pkgConfig.Path, err
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, ui.ErrorStyle.Render(fmt.Sprintf("Error: %v", err)))
@@ -34,8 +86,13 @@ func Execute() {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "配置文件路径（代替仓库根目录下的 tagger.config.json）")
 	rootCmd.Flags().StringVarP(&tagMessage, "message", "m", "", "Tag 消息（创建 annotated tag）")
 	rootCmd.Flags().BoolVar(&autoPush, "push", false, "自动推送到远程")
 	rootCmd.Flags().BoolVar(&noPush, "no-push", false, "不推送到远程")
 	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "模拟运行")
+	rootCmd.Flags().BoolVar(&force, "force", false, "允许自定义版本不大于当前版本")
+	rootCmd.Flags().StringVarP(&tagPackage, "package", "p", "", "为 packages 中配置的包创建 tag（monorepo）")
+	rootCmd.Flags().StringVar(&tagPreid, "preid", "", "新版本的预发布标识模板，例如 beta 或 nightly.{{.Date}}（默认使用配置中的 preid）")
+	rootCmd.Flags().StringVar(&tagMetadata, "metadata", "", "新版本的构建元数据模板，例如 build.{{.Env.BUILD_NUMBER}}（默认使用配置中的 metadata）")
 }
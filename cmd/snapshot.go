@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/semver"
+	semverlib "github.com/Masterminds/semver/v3"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotFormat   string
+	snapshotTemplate string
+	snapshotPackage  string
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "输出未打 tag 的构建使用的版本号",
+	Long: `根据可以从 HEAD 到达的最新的 tag 和 HEAD 计算版本号并输出到标准输出，用于 CI 中两次发布之间的构建。
+HEAD 正好是最新的 tag 时输出该 tag；已跟踪的文件有未提交的修改时追加 dirtyMark（与 git describe --dirty 一致）`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runSnapshot(snapshotFormat, snapshotTemplate, snapshotPackage)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().StringVarP(&snapshotFormat, "format", "f", "", "版本格式：go（Go 伪版本）或 template（默认使用配置中的 snapshot.format）")
+	snapshotCmd.Flags().StringVar(&snapshotTemplate, "template", "", "template 格式使用的模板，指定时 format 默认为 template")
+	snapshotCmd.Flags().StringVarP(&snapshotPackage, "package", "p", "", "使用 packages 中配置的包的版本（monorepo）")
+}
+
+func runSnapshot(format, tmpl, pkg string) error {
+	gitClient := git.NewGitClient(".")
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// 命令行参数优先于配置
+	settings := cfg.SnapshotSettings()
+	if tmpl != "" {
+		settings.Template = tmpl
+		settings.Format = config.SnapshotTemplate
+	}
+	if format != "" {
+		settings.Format = config.SnapshotFormat(format)
+	}
+	if settings.Format != config.SnapshotGo && settings.Format != config.SnapshotTemplate {
+		return fmt.Errorf("invalid format %q (must be go or template)", settings.Format)
+	}
+
+	versionMgr, _, err := packageVersionManager(cfg, pkg)
+	if err != nil {
+		return err
+	}
+
+	versionTags, err := loadMergedVersionTags(gitClient, versionMgr)
+	if err != nil {
+		return err
+	}
+	suffix, err := dirtySuffix(gitClient, settings)
+	if err != nil {
+		return err
+	}
+
+	version, _, err := snapshotVersion(gitClient, versionMgr, settings, versionMgr.GetLatestVersion(versionTags))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(version + suffix)
+	return nil
+}
+
+// dirtySuffix 已跟踪的文件有未提交的修改时返回 dirtyMark，否则返回空字符串
+func dirtySuffix(gitClient *git.GitClient, settings config.SnapshotConfig) (string, error) {
+	dirty, err := gitClient.HasTrackedChanges()
+	if err != nil {
+		return "", err
+	}
+	if dirty {
+		return settings.DirtyMark, nil
+	}
+	return "", nil
+}
+
+// snapshotVersion 根据可以从 HEAD 到达的最新的 tag 计算 HEAD 的版本，不包含 dirtyMark
+// 与 git describe 一致，HEAD 正好是最新的 tag 时返回该 tag 的版本，exact 为 true
+func snapshotVersion(gitClient *git.GitClient, versionMgr *semver.VersionManager, settings config.SnapshotConfig, latest semver.VersionTag) (version string, exact bool, err error) {
+	head, err := gitClient.GetCommit("HEAD")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+
+	if latest.Name != "" {
+		tagCommit, err := gitClient.ResolveCommit(latest.Name)
+		if err != nil {
+			return "", false, err
+		}
+		if tagCommit == head.Hash {
+			return snapshotTag(settings.Format, latest), true, nil
+		}
+	}
+
+	commitTime, err := gitClient.GetCommitTime("HEAD")
+	if err != nil {
+		return "", false, err
+	}
+
+	switch settings.Format {
+	case config.SnapshotGo:
+		var base *semverlib.Version
+		if latest.Tagged() {
+			base = latest.Version
+		}
+		return versionMgr.PseudoVersion(base, commitTime, head.Hash), false, nil
+	case config.SnapshotTemplate:
+		data := versionMgr.NewSnapshotData(latest.Version)
+		data.Commit = head.Hash
+		data.ShortCommit = head.ShortHash
+		data.Timestamp = commitTime.UTC().Format("20060102150405")
+		data.Distance, err = gitClient.CountCommits(latest.Name, "HEAD")
+		if err != nil {
+			return "", false, err
+		}
+		data.Branch, err = gitClient.GetCurrentBranch()
+		if err != nil {
+			return "", false, err
+		}
+
+		version, err = semver.RenderSnapshot(settings.Template, data)
+		if err != nil {
+			return "", false, err
+		}
+		return version, false, nil
+	}
+	return "", false, fmt.Errorf("invalid format %q (must be go or template)", settings.Format)
+}
+
+// loadMergedVersionTags 只加载可以从 HEAD 到达的版本 tag
+// 其他分支上更高的版本不能作为基础版本：Go 要求伪版本的基础 tag 是祖先，距离也只能从祖先开始计算
+func loadMergedVersionTags(gitClient *git.GitClient, versionMgr *semver.VersionManager) ([]semver.VersionTag, error) {
+	tagInfos, err := gitClient.GetTagsWithDates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	merged, err := gitClient.GetMergedTags("HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var reachable []git.TagInfo
+	for _, tag := range tagInfos {
+		if merged[tag.Name] {
+			reachable = append(reachable, tag)
+		}
+	}
+
+	versionTags, err := versionMgr.ParseTags(reachable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+	warnDuplicateTags(versionTags)
+	return versionTags, nil
+}
+
+// snapshotTag 返回 HEAD 正好是 tag 时的版本，Go 格式使用不含目录前缀的模块版本
+func snapshotTag(format config.SnapshotFormat, tag semver.VersionTag) string {
+	if format == config.SnapshotGo {
+		return "v" + tag.Version.String()
+	}
+	return tag.Name
+}
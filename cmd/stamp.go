@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/AkaraChen/tagger/internal/versionfile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stampPackage string
+	stampDryRun  bool
+)
+
+var stampCmd = &cobra.Command{
+	Use:   "stamp",
+	Short: "重新生成 Go 版本文件",
+	Long: `根据可以从 HEAD 到达的最新的 tag 和 HEAD 重新生成 goVersionFile 配置的 Go 版本文件，不创建 tag 也不提交，用于本地构建。
+HEAD 正好是最新的 tag 时使用该版本，否则与 tagger snapshot 一样使用 snapshot 配置计算的版本（不包含 tag 前缀）`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runStamp(stampPackage, stampDryRun)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stampCmd)
+	stampCmd.Flags().StringVarP(&stampPackage, "package", "p", "", "使用 packages 中配置的包的版本（monorepo）")
+	stampCmd.Flags().BoolVar(&stampDryRun, "dry-run", false, "只输出 diff，不写入文件")
+}
+
+func runStamp(pkg string, dryRun bool) error {
+	gitClient := git.NewGitClient(".")
+
+	isRepo, err := gitClient.IsGitRepository()
+	if err != nil {
+		return fmt.Errorf("failed to check git repository: %w", err)
+	}
+	if !isRepo {
+		return fmt.Errorf("not a git repository (or any of the parent directories)")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg == nil || cfg.GoVersionFile == nil {
+		return fmt.Errorf("goVersionFile is not configured (run tagger config set goVersionFile.path internal/version/version_gen.go)")
+	}
+
+	versionMgr, _, err := packageVersionManager(cfg, pkg)
+	if err != nil {
+		return err
+	}
+
+	versionTags, err := loadMergedVersionTags(gitClient, versionMgr)
+	if err != nil {
+		return err
+	}
+	latest := versionMgr.GetLatestVersion(versionTags)
+
+	settings := cfg.SnapshotSettings()
+	suffix, err := dirtySuffix(gitClient, settings)
+	if err != nil {
+		return err
+	}
+	snapshot, exact, err := snapshotVersion(gitClient, versionMgr, settings, latest)
+	if err != nil {
+		return err
+	}
+
+	// 版本文件中的版本不包含 tag 前缀；HEAD 不是最新的 tag 时使用 snapshot 版本，上一个版本为最新的 tag
+	version := versionMgr.VersionString(latest.Version)
+	previousVersion := ""
+	if exact {
+		if previous := versionMgr.GetPreviousVersion(versionTags, latest.Version); previous != nil {
+			previousVersion = versionMgr.VersionString(previous.Version)
+		}
+	} else {
+		if settings.Format == config.SnapshotGo {
+			version = strings.TrimPrefix(snapshot, "v")
+		} else {
+			version = strings.TrimPrefix(snapshot, versionMgr.Prefix)
+		}
+		if latest.Tagged() {
+			previousVersion = versionMgr.VersionString(latest.Version)
+		}
+	}
+	version += suffix
+
+	head, err := gitClient.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	repoRoot, err := gitClient.GetTopLevel()
+	if err != nil {
+		return err
+	}
+
+	change, err := versionfile.GenerateGo(repoRoot, *cfg.GoVersionFile, releaseMetadata(version, previousVersion, head))
+	if err != nil {
+		return fmt.Errorf("failed to generate Go version file: %w", err)
+	}
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would write %s", change.Path)))
+		fmt.Print(colorDiff(change.Diff()))
+		return nil
+	}
+
+	if err := versionfile.Write(repoRoot, change); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Wrote %s (%s)", change.Path, version)))
+	return nil
+}
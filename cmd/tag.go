@@ -3,27 +3,46 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+	"time"
 
+	"github.com/AkaraChen/tagger/internal/changelog"
 	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/AkaraChen/tagger/internal/editor"
 	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/hooks"
+	"github.com/AkaraChen/tagger/internal/message"
 	"github.com/AkaraChen/tagger/internal/semver"
 	"github.com/AkaraChen/tagger/internal/ui"
+	"github.com/AkaraChen/tagger/internal/versionfile"
+	semverlib "github.com/Masterminds/semver/v3"
 )
 
-// RunTag 执行 tag 创建命令
-func RunTag(message string, autoPush, noPush, dryRun bool) error {
+// RunTag 执行 tag 创建命令，pkg 不为空时为 packages 中配置的包创建 tag
+// preid 和 metadata 为预发布标识和构建元数据的模板，为空时使用配置
+func RunTag(message string, autoPush, noPush, dryRun, force bool, pkg, preid, metadata string) error {
 	// 1. 初始化
 	gitClient := git.NewGitClient(".")
-	versionMgr := semver.NewVersionManager()
 
 	// 加载配置文件
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// 根据配置的 tag 前缀解析版本，monorepo 中的包只统计其目录下的 commits
+	versionMgr, moduleDir, err := packageVersionManager(cfg, pkg)
+	if err != nil {
+		return err
+	}
+	var paths []string
+	if moduleDir != "" {
+		paths = []string{":(top)" + moduleDir}
+	}
+
 	// 2. 检查是否在 git 仓库中
 	isRepo, err := gitClient.IsGitRepository()
 	if err != nil {
@@ -42,86 +61,202 @@ func RunTag(message string, autoPush, noPush, dryRun bool) error {
 		fmt.Println(ui.InfoStyle.Render("⚠ Warning: You have uncommitted changes"))
 	}
 
-	// 4. 获取所有 tags
-	tags, err := gitClient.GetAllTags()
+	// 4. 获取并解析所有 tags，找到最新版本
+	versionTags, err := loadVersionTags(gitClient, versionMgr)
 	if err != nil {
-		return fmt.Errorf("failed to get tags: %w", err)
+		return err
+	}
+
+	current := versionMgr.GetLatestVersion(versionTags)
+	currentVersion := current.Version
+	currentVersionStr := current.Name
+	if !current.Tagged() {
+		currentVersionStr = versionMgr.FormatVersion(currentVersion)
 	}
 
-	// 5. 解析 tags，找到最新版本
-	versions, err := versionMgr.ParseTags(tags)
+	// 6. 收集向导需要的信息：目标 commit 和远程仓库
+	headCommit, err := gitClient.GetCommit("HEAD")
 	if err != nil {
-		return fmt.Errorf("failed to parse tags: %w", err)
+		return fmt.Errorf("failed to get HEAD commit: %w", err)
 	}
 
-	currentVersion := versionMgr.GetLatestVersion(versions)
-	currentVersionStr := versionMgr.FormatVersion(currentVersion)
+	// 命令行参数优先于配置中的 preid 和 metadata
+	if preid == "" && cfg != nil {
+		preid = cfg.Preid
+	}
+	if metadata == "" && cfg != nil {
+		metadata = cfg.Metadata
+	}
+	decorate, err := versionDecorator(gitClient, versionMgr, versionTags, headCommit, preid, metadata)
+	if err != nil {
+		return err
+	}
 
 	// 计算所有可能的新版本（用于显示预览）
-	patchVersion := versionMgr.FormatVersion(versionMgr.BumpPatch(currentVersion))
-	minorVersion := versionMgr.FormatVersion(versionMgr.BumpMinor(currentVersion))
-	majorVersion := versionMgr.FormatVersion(versionMgr.BumpMajor(currentVersion))
+	// 可选的更新类型由版本方案决定，无法计算的类型（例如当前周期已发布）不显示
+	var choices []ui.BumpChoice
+	for _, bump := range versionMgr.BumpTypes(currentVersion) {
+		next, err := versionMgr.CalculateNewVersion(currentVersion, bump.Type)
+		if err != nil {
+			continue
+		}
+		next, err = decorate(next)
+		if err != nil {
+			return err
+		}
+		choices = append(choices, ui.BumpChoice{Type: bump.Type, Version: versionMgr.FormatVersion(next), Label: bump.Label})
+	}
 
-	// 6. 使用 Bubble Tea 选择更新类型
-	bumpType, err := ui.SelectBumpType(currentVersionStr, patchVersion, minorVersion, majorVersion)
+	hasRemote, err := gitClient.HasRemote()
 	if err != nil {
-		if err.Error() == "cancelled" {
-			fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
-			return nil
+		return fmt.Errorf("failed to check remote: %w", err)
+	}
+
+	remoteName := ""
+	if hasRemote {
+		remoteName, err = gitClient.GetRemoteName()
+		if err != nil {
+			return fmt.Errorf("failed to get remote name: %w", err)
 		}
-		return fmt.Errorf("failed to select bump type: %w", err)
 	}
 
-	// 7. 计算新版本号
-	newVersion, err := versionMgr.CalculateNewVersion(currentVersion, bumpType)
+	// 收集自上个版本以来的 commits，用于生成变更日志和 message 模板
+	previousTag := current.Name
+	commits, err := gitClient.GetCommitsBetween(previousTag, "HEAD", paths...)
 	if err != nil {
-		return fmt.Errorf("failed to calculate new version: %w", err)
+		return fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	repoRoot, err := gitClient.GetTopLevel()
+	if err != nil {
+		return err
 	}
-	newVersionStr := versionMgr.FormatVersion(newVersion)
 
-	// 8. 处理 tag message
-	tagMessage := message
-	if tagMessage == "" {
-		// 询问是否添加 message
-		addMessage, err := ui.ConfirmAddMessage()
+	// 根据 Go 模块导出 API 的变化建议更新类型，分析失败不影响创建 tag
+	var recommendation *ui.Recommendation
+	if cfg.AnalyzeAPI() && versionMgr.IsSemVer() {
+		recommendation, err = analyzeAPI(gitClient, repoRoot, moduleDir, previousTag)
 		if err != nil {
-			if err.Error() == "cancelled" {
-				fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
-				return nil
-			}
-			return fmt.Errorf("failed to confirm add message: %w", err)
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("⚠ Warning: API analysis failed: %v", err)))
 		}
+	}
 
-		// 9. 如果用户选择添加 message，打开 textarea
-		if addMessage {
-			defaultText := fmt.Sprintf("Release %s: ", newVersionStr)
-			tagMessage, err = ui.InputTagMessage(defaultText)
-			if err != nil {
-				if err.Error() == "cancelled" {
-					fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
-					return nil
-				}
-				return fmt.Errorf("failed to input tag message: %w", err)
-			}
+	changelogText := ""
+	if cfg.IncludeChangelog() {
+		changelogText = changelog.Markdown(changelog.GroupCommits(commits))
+	}
+	defaultMessage := func(version string) string {
+		return fmt.Sprintf("Release %s: ", version)
+	}
+
+	// 配置了 messageTemplate 时使用模板生成默认 message
+	// 某个版本渲染失败时使用上面的默认 message，向导结束后输出警告（向导运行时无法输出）
+	useTemplate := cfg != nil && cfg.MessageTemplate != ""
+	templateErrors := make(map[string]error)
+	if useTemplate {
+		fallback := defaultMessage
+		defaultMessage, err = templateMessage(cfg, gitClient, previousTag, headCommit, commits, func(version string, err error) string {
+			templateErrors[version] = err
+			return fallback(version)
+		})
+		if err != nil {
+			return err
 		}
+
+		// 模板中已经可以使用变更日志，编辑器中不再重复附加
+		changelogText = ""
+	}
+
+	coreEditor, err := gitClient.GetConfig("core.editor")
+	if err != nil {
+		return err
+	}
+	commentChar, err := gitClient.GetConfig("core.commentChar")
+	if err != nil {
+		return err
 	}
 
-	// 10. 确认创建 tag
-	confirmed, err := ui.ConfirmCreateTag(currentVersionStr, newVersionStr, tagMessage)
+	// 命令行参数优先于配置中的推送方式
+	pushMode := cfg.PushPolicy()
+	switch {
+	case autoPush:
+		pushMode = config.PushAlways
+	case noPush:
+		pushMode = config.PushNever
+	}
+	sign := cfg != nil && cfg.Sign
+
+	// 7. 使用 Bubble Tea 向导选择版本、输入 message、选择是否推送并确认
+	result, err := ui.RunTagWizard(ui.TagWizardOptions{
+		CurrentVersion: currentVersionStr,
+		Choices:        choices,
+		Recommendation: recommendation,
+		ValidateCustom: func(input string) (string, string, error) {
+			v, err := versionMgr.ParseInput(input)
+			if err != nil {
+				return "", "", fmt.Errorf("invalid semantic version: %s", input)
+			}
+			warning, err := versionMgr.CheckCustomVersion(currentVersion, v, force)
+			return versionMgr.FormatVersion(v), warning, err
+		},
+		DefaultMessage:   defaultMessage,
+		MessageByDefault: useTemplate,
+		Changelog:        changelogText,
+		Editor:           editor.Resolve(coreEditor),
+		CommentChar:      commentChar,
+		OpenEditor:       cfg != nil && cfg.MessageEditor,
+		Message:          message,
+		Commit:           fmt.Sprintf("%s %s", headCommit.ShortHash, headCommit.Subject),
+		AskPush:          hasRemote && pushMode == config.PushAsk,
+		Push:             hasRemote && pushMode == config.PushAlways,
+		Sign:             sign,
+		Remote:           remoteName,
+		DryRun:           dryRun,
+	})
 	if err != nil {
 		if err.Error() == "cancelled" {
 			fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
 			return nil
 		}
-		return fmt.Errorf("failed to confirm create tag: %w", err)
+		return fmt.Errorf("failed to run tag wizard: %w", err)
 	}
 
-	if !confirmed {
-		fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
-		return nil
+	// 8. 计算新版本号，自定义版本已在向导中校验，不再添加 preid 和 metadata
+	var newVersion *semverlib.Version
+	if result.BumpType == "custom" {
+		newVersion, err = versionMgr.ParseVersion(result.Version)
+	} else {
+		newVersion, err = versionMgr.CalculateNewVersion(currentVersion, result.BumpType)
+		if err == nil {
+			newVersion, err = decorate(newVersion)
+		}
 	}
+	if err != nil {
+		return fmt.Errorf("failed to calculate new version: %w", err)
+	}
+	newVersionStr := versionMgr.FormatVersion(newVersion)
 
-	// 11. 检查 tag 是否已存在
+	if result.Warning != "" {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("⚠ Warning: %s", result.Warning)))
+	}
+	if err := templateErrors[result.Version]; err != nil {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("⚠ Warning: messageTemplate failed for %s, the default message was used: %v", result.Version, err)))
+	}
+	tagMessage := result.Message
+
+	// 初始开发阶段发布 1.0.0 意味着公开 API 已稳定，需要再次确认，默认不继续
+	if versionMgr.Stabilizes(currentVersion, newVersion) {
+		confirmed, err := ui.Confirm(fmt.Sprintf("%s ends initial development: the public API becomes stable and breaking changes will require a new major version. Continue?", newVersionStr), false)
+		if err != nil && err.Error() != "cancelled" {
+			return fmt.Errorf("failed to confirm: %w", err)
+		}
+		if !confirmed {
+			fmt.Println(ui.InfoStyle.Render("Operation cancelled"))
+			return nil
+		}
+	}
+
+	// 9. 检查 tag 是否已存在
 	exists, err := gitClient.TagExists(newVersionStr)
 	if err != nil {
 		return fmt.Errorf("failed to check tag existence: %w", err)
@@ -130,60 +265,94 @@ func RunTag(message string, autoPush, noPush, dryRun bool) error {
 		return fmt.Errorf("tag %s already exists", newVersionStr)
 	}
 
-	// 12. 创建 tag
-	if dryRun {
-		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would create tag %s", newVersionStr)))
-		if tagMessage != "" {
-			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("   Message: %s", tagMessage)))
-		}
-	} else {
-		if tagMessage != "" {
-			err = gitClient.CreateAnnotatedTag(newVersionStr, tagMessage)
-		} else {
-			err = gitClient.CreateTag(newVersionStr)
-		}
-
+	// Go 模块的主版本变化时检查 go.mod 中模块路径的 /vN 后缀，改写的文件加入 release commit
+	var moduleChanges []versionfile.Change
+	if versionMgr.IsSemVer() && newVersion.Major() != currentVersion.Major() {
+		moduleChanges, err = checkModuleMajor(repoRoot, moduleDir, newVersion.Major())
 		if err != nil {
-			return fmt.Errorf("failed to create tag: %w", err)
+			return err
 		}
+	}
 
-		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Tag %s created successfully!", newVersionStr)))
+	// 生命周期钩子在仓库根目录中执行
+	hookCommands := cfg.HookCommands()
+	hookRunner := hooks.Runner{Dir: repoRoot, Out: os.Stdout, Prefix: ui.HelpStyle.Render("│ ")}
+	hookEnv := hooks.Env{
+		Version: versionMgr.VersionString(newVersion),
+		Tag:     newVersionStr,
+		Commit:  headCommit.Hash,
+		DryRun:  dryRun,
+	}
+	if current.Tagged() {
+		hookEnv.PreviousVersion = versionMgr.VersionString(currentVersion)
 	}
 
-	// 13. 检查是否有远程仓库
-	hasRemote, err := gitClient.HasRemote()
+	// pre 钩子失败时中止，两个钩子都在写入版本文件和创建 release commit 之前执行，
+	// 因此失败时仓库中不会留下任何修改
+	if err := runHook(hookRunner, hooks.PreBump, hookCommands.PreBump, hookEnv); err != nil {
+		return err
+	}
+	if err := runHook(hookRunner, hooks.PreTag, hookCommands.PreTag, hookEnv); err != nil {
+		return err
+	}
+
+	// 将新版本写入 versionFiles 和 Go 版本文件并提交，tag 指向 release commit
+	meta := releaseMetadata(hookEnv.Version, hookEnv.PreviousVersion, headCommit.Hash)
+	releaseChanges, err := prepareRelease(cfg, repoRoot, meta)
 	if err != nil {
-		return fmt.Errorf("failed to check remote: %w", err)
+		return err
+	}
+	releaseChanges = append(releaseChanges, moduleChanges...)
+	if err := commitRelease(gitClient, repoRoot, releaseCommitMessage(newVersionStr), releaseChanges, dryRun); err != nil {
+		return err
+	}
+	if len(releaseChanges) > 0 && !dryRun {
+		releaseCommit, err := gitClient.ResolveCommit("HEAD")
+		if err != nil {
+			return err
+		}
+		hookEnv.Commit = releaseCommit
+	}
+
+	// 10. 创建 tag
+	if err := createTag(gitClient, newVersionStr, tagMessage, sign, dryRun); err != nil {
+		return err
 	}
 
+	// post 钩子失败不影响已创建的 tag
+	runPostHook(hookRunner, hooks.PostTag, hookCommands.PostTag, hookEnv)
+
 	if !hasRemote {
 		fmt.Println(ui.InfoStyle.Render("No remote repository configured, skipping push"))
 		return nil
 	}
 
-	// 14. 处理推送
-	shouldPush := false
-
-	if autoPush {
-		shouldPush = true
-	} else if !noPush {
-		// 询问是否推送
-		confirmed, err := ui.ConfirmPush(newVersionStr)
-		if err != nil {
-			if err.Error() == "cancelled" {
-				fmt.Println(ui.InfoStyle.Render("Skipping push"))
-				return nil
-			}
-			return fmt.Errorf("failed to confirm push: %w", err)
+	// 11. 推送 tag
+	if result.Push {
+		if err := runHook(hookRunner, hooks.PrePush, hookCommands.PrePush, hookEnv); err != nil {
+			return fmt.Errorf("%w (tag %s was created but not pushed)", err, newVersionStr)
 		}
-		shouldPush = confirmed
-	}
 
-	// 15. 推送 tag
-	if shouldPush {
 		if dryRun {
+			if len(releaseChanges) > 0 {
+				fmt.Println(ui.InfoStyle.Render("🔍 Dry run: Would push the release commit to remote"))
+			}
 			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would push tag %s to remote", newVersionStr)))
 		} else {
+			// release commit 需要随 tag 一起推送到分支上
+			if len(releaseChanges) > 0 {
+				fmt.Print(ui.InfoStyle.Render("⠋ Pushing release commit to remote..."))
+				err = gitClient.PushBranch()
+				fmt.Print("\r")
+
+				// 分支推送失败时不推送 tag，否则远程仓库中的 tag 会指向不在任何分支上的 commit
+				if err != nil {
+					fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("✗ Failed to push release commit: %v", err)))
+					fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("  You can manually push with: git push %s HEAD %s", remoteName, newVersionStr)))
+					return nil // 不返回错误，因为 tag 已经创建成功
+				}
+			}
+
 			fmt.Print(ui.InfoStyle.Render("⠋ Pushing tag to remote..."))
 			err = gitClient.PushTag(newVersionStr)
 			fmt.Print("\r") // 清除 spinner
@@ -195,6 +364,7 @@ func RunTag(message string, autoPush, noPush, dryRun bool) error {
 			}
 
 			fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Tag %s pushed to remote successfully!", newVersionStr)))
+			runPostHook(hookRunner, hooks.PostPush, hookCommands.PostPush, hookEnv)
 
 			// 处理打开仓库的逻辑，优先使用配置文件
 			if err := handleOpenRepository(cfg, gitClient); err != nil {
@@ -209,6 +379,195 @@ func RunTag(message string, autoPush, noPush, dryRun bool) error {
 	return nil
 }
 
+// createTag 创建 tag，message 为空时创建 lightweight tag，sign 为 true 时创建签名 tag
+func createTag(gitClient *git.GitClient, name, message string, sign, dryRun bool) error {
+	if dryRun {
+		if sign {
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would create signed tag %s", name)))
+		} else {
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("🔍 Dry run: Would create tag %s", name)))
+		}
+		if message != "" {
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("   Message: %s", message)))
+		}
+		return nil
+	}
+
+	var err error
+	switch {
+	case sign:
+		// 签名 tag 必须带有 message，没有 message 时使用 tag 名
+		signMessage := message
+		if signMessage == "" {
+			signMessage = name
+		}
+		err = gitClient.CreateSignedTag(name, signMessage)
+	case message != "":
+		err = gitClient.CreateAnnotatedTag(name, message)
+	default:
+		err = gitClient.CreateTag(name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Tag %s created successfully!", name)))
+	return nil
+}
+
+// runHook 执行一个钩子并输出其名称和命令，未配置时不做任何事
+func runHook(runner hooks.Runner, name, command string, env hooks.Env) error {
+	if command == "" {
+		return nil
+	}
+
+	fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("▶ Running %s hook: %s", name, command)))
+	if err := runner.Run(name, command, env); err != nil {
+		return err
+	}
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ %s hook finished", name)))
+	return nil
+}
+
+// runPostHook 执行 post 钩子，失败时只输出警告
+func runPostHook(runner hooks.Runner, name, command string, env hooks.Env) {
+	if err := runHook(runner, name, command, env); err != nil {
+		fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("✗ %v", err)))
+	}
+}
+
+// templateMessage 解析 messageTemplate，返回根据新版本渲染 message 的函数
+// 模板在进入向导前会试渲染一次，以便尽早报告模板错误；之后某个版本渲染失败时使用 fallback 返回的 message
+func templateMessage(cfg *config.Config, gitClient *git.GitClient, previousTag string, head git.CommitInfo, commits []git.CommitInfo, fallback func(version string, err error) string) (func(string) string, error) {
+	tmpl, err := message.Parse(cfg.MessageTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	branch, err := gitClient.GetCurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := gitClient.GetConfig("user.name")
+	if err != nil {
+		return nil, err
+	}
+	if author == "" {
+		author = head.Author
+	}
+
+	repoURL := ""
+	if hasRemote, _ := gitClient.HasRemote(); hasRemote {
+		repoURL, _ = gitClient.GetRemoteURL()
+	}
+
+	groups := changelog.GroupCommits(commits)
+	data := message.Data{
+		PreviousVersion: previousTag,
+		Date:            time.Now().Format("2006-01-02"),
+		Branch:          branch,
+		Commit:          head.Hash,
+		ShortCommit:     head.ShortHash,
+		Author:          author,
+		Commits:         commits,
+		Groups:          groups,
+		Changelog:       changelog.Markdown(groups),
+	}
+
+	render := func(version string) (string, error) {
+		d := data
+		d.Version = version
+		d.CompareURL = compareURL(cfg, repoURL, previousTag, version)
+		return tmpl.Render(d)
+	}
+
+	if _, err := render("v0.0.0"); err != nil {
+		return nil, err
+	}
+
+	return func(version string) string {
+		text, err := render(version)
+		if err != nil {
+			return fallback(version, err)
+		}
+		return text
+	}, nil
+}
+
+// versionDecorator 渲染 preid 和 metadata 模板，返回为计算出的新版本添加预发布标识和构建元数据的函数
+func versionDecorator(gitClient *git.GitClient, versionMgr *semver.VersionManager, versionTags []semver.VersionTag, head git.CommitInfo, preid, metadata string) (func(*semverlib.Version) (*semverlib.Version, error), error) {
+	if preid == "" && metadata == "" {
+		return func(v *semverlib.Version) (*semverlib.Version, error) { return v, nil }, nil
+	}
+
+	branch, err := gitClient.GetCurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+
+	now := time.Now().UTC()
+	data := semver.IdentifierData{
+		Date:        now.Format("20060102"),
+		Timestamp:   now.Format("20060102150405"),
+		Commit:      head.Hash,
+		ShortCommit: head.ShortHash,
+		Branch:      branch,
+		Env:         env,
+	}
+
+	preid, err = semver.RenderIdentifier("preid", preid, data)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err = semver.RenderIdentifier("metadata", metadata, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v *semverlib.Version) (*semverlib.Version, error) {
+		v, err := versionMgr.WithPrerelease(v, preid, versionTags)
+		if err != nil {
+			return nil, err
+		}
+		return versionMgr.WithMetadata(v, metadata)
+	}, nil
+}
+
+// loadVersionTags 读取所有 tags 并解析为版本，多个 tag 解析为同一个版本时输出警告
+func loadVersionTags(gitClient *git.GitClient, versionMgr *semver.VersionManager) ([]semver.VersionTag, error) {
+	tagInfos, err := gitClient.GetTagsWithDates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	versionTags, err := versionMgr.ParseTags(tagInfos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+	warnDuplicateTags(versionTags)
+	return versionTags, nil
+}
+
+// warnDuplicateTags 对解析为同一个版本的多个 tag 输出警告，警告写入 stderr 以免影响脚本读取输出
+func warnDuplicateTags(versionTags []semver.VersionTag) {
+	for _, tag := range versionTags {
+		if len(tag.Duplicates) == 0 {
+			continue
+		}
+		fmt.Fprintln(os.Stderr, ui.InfoStyle.Render(fmt.Sprintf("⚠ Warning: tags %s and %s parse to the same version; using %s",
+			tag.Name, strings.Join(tag.Duplicates, ", "), tag.Name)))
+	}
+}
+
 // openBrowser 在默认浏览器中打开 URL
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
@@ -239,6 +598,30 @@ func isGitHub(rawURL string) bool {
 	return parsedURL.Hostname() == "github.com"
 }
 
+// compareURL 返回托管平台上比较两个 ref 的页面地址，无法识别平台时返回空字符串
+func compareURL(cfg *config.Config, repoURL, from, to string) string {
+	if repoURL == "" || from == "" || to == "" {
+		return ""
+	}
+
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return ""
+	}
+	host := parsedURL.Hostname()
+
+	switch {
+	case cfg.IsGitHub() || isGitHub(repoURL):
+		return fmt.Sprintf("%s/compare/%s...%s", repoURL, from, to)
+	case strings.Contains(host, "gitlab"):
+		return fmt.Sprintf("%s/-/compare/%s...%s", repoURL, from, to)
+	case strings.Contains(host, "bitbucket"):
+		return fmt.Sprintf("%s/branches/compare/%s%%0D%s", repoURL, to, from)
+	default:
+		return ""
+	}
+}
+
 // handleOpenRepository 处理打开仓库的逻辑，优先使用配置文件
 func handleOpenRepository(cfg *config.Config, gitClient *git.GitClient) error {
 	// 获取远程仓库 URL
@@ -0,0 +1,404 @@
+package apidiff
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Level API 变化的程度
+type Level int
+
+const (
+	// None 导出的 API 没有变化
+	None Level = iota
+	// Compatible 只新增了 API
+	Compatible
+	// Incompatible 删除或修改了已有的 API
+	Incompatible
+)
+
+// BumpType 返回 API 变化对应的更新类型
+func (l Level) BumpType() string {
+	switch l {
+	case Incompatible:
+		return "major"
+	case Compatible:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+func (l Level) String() string {
+	switch l {
+	case Incompatible:
+		return "incompatible"
+	case Compatible:
+		return "compatible"
+	default:
+		return "none"
+	}
+}
+
+// API 一个模块导出的 API，key 为包相对于模块根目录的路径
+type API map[string]Package
+
+// Package 一个包导出的标识符，key 描述标识符（例如 func Foo、field T.X），value 为其签名
+type Package map[string]string
+
+// Change 一处 API 变化
+type Change struct {
+	// Package 包相对于模块根目录的路径，根目录为 .
+	Package    string
+	Message    string
+	Compatible bool
+}
+
+// Report 两个版本之间的 API 比较结果
+type Report struct {
+	Changes []Change
+}
+
+// Level 返回所有变化中最严重的程度
+func (r Report) Level() Level {
+	level := None
+	for _, c := range r.Changes {
+		if !c.Compatible {
+			return Incompatible
+		}
+		level = Compatible
+	}
+	return level
+}
+
+// String 返回按不兼容、兼容分组的可读报告
+func (r Report) String() string {
+	if len(r.Changes) == 0 {
+		return "No changes to the exported API"
+	}
+
+	var incompatible, compatible []string
+	for _, c := range r.Changes {
+		line := fmt.Sprintf("  %s: %s", c.Package, c.Message)
+		if c.Compatible {
+			compatible = append(compatible, line)
+		} else {
+			incompatible = append(incompatible, line)
+		}
+	}
+
+	var sections []string
+	if len(incompatible) > 0 {
+		sections = append(sections, "Incompatible changes:\n"+strings.Join(incompatible, "\n"))
+	}
+	if len(compatible) > 0 {
+		sections = append(sections, "Compatible changes:\n"+strings.Join(compatible, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// Compare 比较两个版本的 API
+func Compare(old, new API) Report {
+	var report Report
+
+	for _, dir := range sortedKeys(old, new) {
+		oldPkg, inOld := old[dir]
+		newPkg, inNew := new[dir]
+
+		switch {
+		case !inNew:
+			report.Changes = append(report.Changes, Change{Package: dir, Message: "package removed"})
+			continue
+		case !inOld:
+			report.Changes = append(report.Changes, Change{Package: dir, Message: "package added", Compatible: true})
+			continue
+		}
+
+		for _, key := range sortedKeys(oldPkg, newPkg) {
+			oldSig, inOld := oldPkg[key]
+			newSig, inNew := newPkg[key]
+
+			switch {
+			case !inNew:
+				report.Changes = append(report.Changes, Change{Package: dir, Message: "removed " + key})
+			case !inOld:
+				// 接口新增方法会破坏已有的实现
+				compatible := !strings.HasPrefix(key, "interface method ")
+				report.Changes = append(report.Changes, Change{Package: dir, Message: "added " + key, Compatible: compatible})
+			case oldSig != newSig:
+				report.Changes = append(report.Changes, Change{
+					Package: dir,
+					Message: fmt.Sprintf("changed %s from %s to %s", key, oldSig, newSig),
+				})
+			}
+		}
+	}
+
+	return report
+}
+
+func sortedKeys[V any](maps ...map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Load 读取 dir 下 Go 模块导出的 API
+// 只解析语法，不进行类型检查，因此不需要下载依赖；internal、testdata、vendor 目录，
+// 嵌套的模块、main 包以及不满足当前平台构建约束的文件会被跳过
+func Load(dir string) (API, error) {
+	api := make(API)
+
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		if file != dir {
+			name := d.Name()
+			if name == "internal" || name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(file, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		pkg, err := loadPackage(file)
+		if err != nil {
+			return err
+		}
+		if pkg != nil {
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+			api[path.Clean(filepath.ToSlash(rel))] = pkg
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API: %w", err)
+	}
+
+	return api, nil
+}
+
+// loadPackage 解析目录中的包，没有可导出 API 的包时返回 nil
+func loadPackage(dir string) (Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if f.Name.Name == "main" {
+			return nil, nil
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	pkg := make(Package)
+	for _, f := range files {
+		collect(fset, f, pkg)
+	}
+	return pkg, nil
+}
+
+// collect 将文件中导出的声明加入 pkg
+func collect(fset *token.FileSet, f *ast.File, pkg Package) {
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !decl.Name.IsExported() {
+				continue
+			}
+			if decl.Recv == nil {
+				pkg["func "+decl.Name.Name] = funcSignature(fset, decl.Type, "")
+				continue
+			}
+
+			recv := decl.Recv.List[0].Type
+			typeName := receiverName(recv)
+			if !ast.IsExported(typeName) {
+				continue
+			}
+			pkg["method "+typeName+"."+decl.Name.Name] = funcSignature(fset, decl.Type, "("+exprString(fset, recv)+") ")
+
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						collectType(fset, spec, pkg)
+					}
+				case *ast.ValueSpec:
+					kind := "var"
+					if decl.Tok == token.CONST {
+						kind = "const"
+					}
+					typ := "untyped"
+					if spec.Type != nil {
+						typ = exprString(fset, spec.Type)
+					}
+					for _, name := range spec.Names {
+						if name.IsExported() {
+							pkg[kind+" "+name.Name] = typ
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// collectType 记录类型本身，以及结构体的导出字段和接口的方法
+func collectType(fset *token.FileSet, spec *ast.TypeSpec, pkg Package) {
+	name := spec.Name.Name
+	params := ""
+	if spec.TypeParams != nil {
+		params = fieldListString(fset, spec.TypeParams, "[", "]", true)
+	}
+
+	if spec.Assign.IsValid() {
+		pkg["type "+name] = params + "= " + exprString(fset, spec.Type)
+		return
+	}
+
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		pkg["type "+name] = params + "struct"
+		for _, field := range t.Fields.List {
+			typ := exprString(fset, field.Type)
+			if len(field.Names) == 0 {
+				embedded := receiverName(field.Type)
+				if ast.IsExported(embedded) {
+					pkg["field "+name+"."+embedded] = typ + " (embedded)"
+				}
+				continue
+			}
+			for _, fieldName := range field.Names {
+				if fieldName.IsExported() {
+					pkg["field "+name+"."+fieldName.Name] = typ
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		pkg["type "+name] = params + "interface"
+		for _, method := range t.Methods.List {
+			if len(method.Names) == 0 {
+				// 嵌入的接口或类型约束
+				embedded := exprString(fset, method.Type)
+				pkg["interface method "+name+"."+embedded] = "(embedded)"
+				continue
+			}
+			for _, methodName := range method.Names {
+				if ft, ok := method.Type.(*ast.FuncType); ok {
+					pkg["interface method "+name+"."+methodName.Name] = funcSignature(fset, ft, "")
+				}
+			}
+		}
+	default:
+		pkg["type "+name] = params + exprString(fset, spec.Type)
+	}
+}
+
+// funcSignature 返回不含参数名的函数签名，例如 func[T any](int, string) (T, error)
+func funcSignature(fset *token.FileSet, ft *ast.FuncType, recv string) string {
+	var sb strings.Builder
+	sb.WriteString("func ")
+	sb.WriteString(recv)
+	if ft.TypeParams != nil {
+		sb.WriteString(fieldListString(fset, ft.TypeParams, "[", "]", true))
+	}
+	sb.WriteString(fieldListString(fset, ft.Params, "(", ")", false))
+	if ft.Results != nil && len(ft.Results.List) > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(fieldListString(fset, ft.Results, "(", ")", false))
+	}
+	return sb.String()
+}
+
+// fieldListString 输出字段列表的类型，withNames 为 true 时保留名称（用于类型参数）
+func fieldListString(fset *token.FileSet, list *ast.FieldList, open, close string, withNames bool) string {
+	var parts []string
+	for _, field := range list.List {
+		typ := exprString(fset, field.Type)
+		count := max(1, len(field.Names))
+		for i := 0; i < count; i++ {
+			if withNames && len(field.Names) > 0 {
+				parts = append(parts, field.Names[i].Name+" "+typ)
+			} else {
+				parts = append(parts, typ)
+			}
+		}
+	}
+	return open + strings.Join(parts, ", ") + close
+}
+
+// receiverName 返回接收者或嵌入字段的类型名，去掉指针、包名和类型参数
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+// exprString 将类型表达式格式化为单行文本
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
@@ -0,0 +1,178 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/git"
+)
+
+// Entry 表示一条解析后的 commit
+type Entry struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	Commit      git.CommitInfo
+}
+
+// Group 表示同一类型的 commit 分组
+type Group struct {
+	Type    string
+	Title   string
+	Entries []Entry
+}
+
+// Contributor 表示一个贡献者及其 commit 数量
+type Contributor struct {
+	Name    string
+	Email   string
+	Commits int
+}
+
+// OtherType 表示无法识别类型的 commit
+const OtherType = "other"
+
+// groupOrder 分组的显示顺序及标题
+var groupOrder = []struct {
+	typ   string
+	title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build"},
+	{"ci", "CI"},
+	{"style", "Style"},
+	{"chore", "Chores"},
+	{"revert", "Reverts"},
+	{OtherType, "Other Changes"},
+}
+
+// conventionalPattern 匹配 Conventional Commits 格式：type(scope)!: description
+var conventionalPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// Parse 按 Conventional Commits 规范解析 commit
+func Parse(commit git.CommitInfo) Entry {
+	entry := Entry{
+		Type:        OtherType,
+		Description: commit.Subject,
+		Commit:      commit,
+	}
+
+	if m := conventionalPattern.FindStringSubmatch(commit.Subject); m != nil {
+		typ := strings.ToLower(m[1])
+		if isKnownType(typ) {
+			entry.Type = typ
+			entry.Scope = m[2]
+			entry.Breaking = m[3] == "!"
+			entry.Description = m[4]
+		}
+	}
+
+	if strings.Contains(commit.Body, "BREAKING CHANGE:") || strings.Contains(commit.Body, "BREAKING-CHANGE:") {
+		entry.Breaking = true
+	}
+
+	return entry
+}
+
+// GroupCommits 将 commits 按类型分组，分组按固定顺序排列，空分组会被省略
+func GroupCommits(commits []git.CommitInfo) []Group {
+	byType := make(map[string][]Entry)
+	for _, commit := range commits {
+		entry := Parse(commit)
+		byType[entry.Type] = append(byType[entry.Type], entry)
+	}
+
+	var groups []Group
+	for _, g := range groupOrder {
+		if entries := byType[g.typ]; len(entries) > 0 {
+			groups = append(groups, Group{Type: g.typ, Title: g.title, Entries: entries})
+		}
+	}
+
+	return groups
+}
+
+// Contributors 统计 commits 的贡献者，按 commit 数量从多到少排列
+func Contributors(commits []git.CommitInfo) []Contributor {
+	index := make(map[string]int)
+	var contributors []Contributor
+
+	for _, commit := range commits {
+		key := strings.ToLower(commit.Email)
+		if key == "" {
+			key = commit.Author
+		}
+
+		if i, ok := index[key]; ok {
+			contributors[i].Commits++
+			continue
+		}
+
+		index[key] = len(contributors)
+		contributors = append(contributors, Contributor{
+			Name:    commit.Author,
+			Email:   commit.Email,
+			Commits: 1,
+		})
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return contributors[i].Commits > contributors[j].Commits
+	})
+
+	return contributors
+}
+
+// Markdown 将分组渲染为 Markdown 列表
+func Markdown(groups []Group) string {
+	var sb strings.Builder
+
+	for i, group := range groups {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("### %s\n\n", group.Title))
+
+		for _, entry := range group.Entries {
+			line := entry.Description
+			if entry.Scope != "" {
+				line = fmt.Sprintf("**%s:** %s", entry.Scope, line)
+			}
+			if entry.Breaking {
+				line = "⚠ " + line
+			}
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", line, entry.Commit.ShortHash))
+		}
+	}
+
+	return sb.String()
+}
+
+// Line 返回纯文本的单行描述，例如 "cli: add diff command (abc1234)"
+func (e Entry) Line() string {
+	line := e.Description
+	if e.Scope != "" {
+		line = fmt.Sprintf("%s: %s", e.Scope, line)
+	}
+	if e.Breaking {
+		line = "⚠ " + line
+	}
+	return fmt.Sprintf("%s (%s)", line, e.Commit.ShortHash)
+}
+
+func isKnownType(typ string) bool {
+	for _, g := range groupOrder {
+		if g.typ == typ && typ != OtherType {
+			return true
+		}
+	}
+	return false
+}
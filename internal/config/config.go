@@ -1,15 +1,18 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"path"
+	"strings"
 )
 
 const ConfigFileName = "tagger.config.json"
 const SchemaURL = "https://raw.githubusercontent.com/AkaraChen/tagger/main/tagger.schema.json"
 
+// ExampleMessageTemplate tagger init 生成的 tag message 模板示例
+const ExampleMessageTemplate = "Release {{.Version}}\n\n{{.Changelog}}"
+
 // GitHostingProvider 表示 Git 托管平台类型
 type GitHostingProvider string
 
@@ -18,41 +21,187 @@ const (
 	Other  GitHostingProvider = "Other"
 )
 
+// EnumValues 返回所有合法的托管平台，用于生成 schema
+func (GitHostingProvider) EnumValues() []string {
+	return []string{string(GitHub), string(Other)}
+}
+
+// DefaultTagPrefix 未配置 tagPrefix 时 tag 名的前缀
+const DefaultTagPrefix = "v"
+
+// PushMode 创建 tag 后是否推送
+type PushMode string
+
+const (
+	PushAsk    PushMode = "ask"
+	PushAlways PushMode = "always"
+	PushNever  PushMode = "never"
+)
+
+// EnumValues 返回所有合法的推送方式，用于生成 schema
+func (PushMode) EnumValues() []string {
+	return []string{string(PushAsk), string(PushAlways), string(PushNever)}
+}
+
+// VersionFileFormat 版本文件的格式
+type VersionFileFormat string
+
+const (
+	VersionFileJSON  VersionFileFormat = "json"
+	VersionFileYAML  VersionFileFormat = "yaml"
+	VersionFileTOML  VersionFileFormat = "toml"
+	VersionFileRegex VersionFileFormat = "regex"
+)
+
+// EnumValues 返回所有合法的版本文件格式，用于生成 schema
+func (VersionFileFormat) EnumValues() []string {
+	return []string{string(VersionFileJSON), string(VersionFileYAML), string(VersionFileTOML), string(VersionFileRegex)}
+}
+
+// VersionScheme 版本号的方案
+type VersionScheme string
+
+const (
+	SchemeSemVer VersionScheme = "semver"
+	SchemeCalVer VersionScheme = "calver"
+)
+
+// EnumValues 返回所有合法的版本方案，用于生成 schema
+func (VersionScheme) EnumValues() []string {
+	return []string{string(SchemeSemVer), string(SchemeCalVer)}
+}
+
+// SnapshotFormat tagger snapshot 输出的版本格式
+type SnapshotFormat string
+
+const (
+	SnapshotGo       SnapshotFormat = "go"
+	SnapshotTemplate SnapshotFormat = "template"
+)
+
+// EnumValues 返回所有合法的 snapshot 格式，用于生成 schema
+func (SnapshotFormat) EnumValues() []string {
+	return []string{string(SnapshotGo), string(SnapshotTemplate)}
+}
+
+// 配置结构体的 tag 同时用于生成 tagger.schema.json：
+//   - description: 配置项的说明
+//   - default: 未设置时的默认值
+//   - required: 为 true 时是必填项
+
 // GitHubConfig GitHub 平台的配置
 type GitHubConfig struct {
 	// 使用指针类型可以区分"未设置"和"false"
-	OpenActionPage *bool `json:"openActionPage,omitempty"`
+	OpenActionPage *bool `json:"openActionPage,omitempty" description:"Whether to open GitHub Actions page after push (true) or repository homepage (false)" default:"true"`
 }
 
 // Config 工具的配置文件结构
 type Config struct {
-	Schema             string             `json:"$schema,omitempty"`
-	GitHostingProvider GitHostingProvider `json:"gitHostingProvider"`
-	GitHub             *GitHubConfig      `json:"github,omitempty"`
+	Schema             string             `json:"$schema,omitempty" description:"JSON Schema reference"`
+	GitHostingProvider GitHostingProvider `json:"gitHostingProvider" description:"Git hosting provider type" default:"GitHub"`
+	GitHub             *GitHubConfig      `json:"github,omitempty" description:"GitHub-specific configuration"`
+	// MessageEditor 为 true 时在编辑器中编写 tag message，而不是使用内置的 textarea
+	MessageEditor bool `json:"messageEditor,omitempty" description:"Compose the tag message in $GIT_EDITOR, core.editor, $VISUAL or $EDITOR instead of the built-in textarea" default:"false"`
+	// MessageTemplate tag message 的 text/template 模板，渲染结果为空时创建 lightweight tag
+	MessageTemplate string        `json:"messageTemplate,omitempty" description:"Go text/template for the tag message. Available fields: .Version, .PreviousVersion, .Date, .Branch, .Commit, .ShortCommit, .Author, .Commits, .Groups, .Changelog, .CompareURL. An empty render creates a lightweight tag"`
+	VersionScheme   VersionScheme `json:"versionScheme,omitempty" description:"Versioning scheme: semver (major.minor.patch) or calver (calendar versions formatted by calverFormat)" default:"semver"`
+	// CalVerFormat 以 . 分隔的 token，例如 YYYY.0M.MICRO
+	CalVerFormat string `json:"calverFormat,omitempty" description:"Format of calendar versions, up to three segments separated by dots. Tokens: YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D and MICRO (last segment only)" default:"YYYY.0M.MICRO"`
+	// InitialDevelopment 为 true 时 0.x 版本中不兼容的更新递增次版本，新功能递增补丁版本，发布 1.0.0 需要 stabilize
+	InitialDevelopment bool `json:"initialDevelopment,omitempty" description:"Follow semver initial development rules on 0.x: breaking changes bump minor, features bump patch, and 1.0.0 is only released by the explicit stabilize choice" default:"false"`
+	// Preid 和 Metadata 可以使用的字段见 semver.IdentifierData
+	Preid    string `json:"preid,omitempty" description:"Go text/template for the prerelease identifier added to new versions, for example nightly.{{.Date}}. A counter is appended unless the last part is numeric. Available fields: .Date, .Timestamp, .Commit, .ShortCommit, .Branch, .Env"`
+	Metadata string `json:"metadata,omitempty" description:"Go text/template for the build metadata added to new versions, for example build.{{.Env.GITHUB_RUN_NUMBER}}. Available fields: .Date, .Timestamp, .Commit, .ShortCommit, .Branch, .Env"`
+	// TagPrefix 使用指针类型以支持空前缀（tag 名为 1.2.3）
+	TagPrefix *string  `json:"tagPrefix,omitempty" description:"Prefix prepended to the version in tag names" default:"v"`
+	Sign      bool     `json:"sign,omitempty" description:"Create GPG-signed tags (git tag -s)" default:"false"`
+	Push      PushMode `json:"push,omitempty" description:"Whether to push the tag after creating it: ask, always or never" default:"ask"`
+	Changelog *bool    `json:"changelog,omitempty" description:"Offer the changelog generated from Conventional Commits when composing the tag message" default:"true"`
+	// APIDiff 为 true 时比较 Go 模块在上个版本和 HEAD 导出的 API，并据此建议更新类型
+	// 分析需要检出两个版本，因此默认关闭
+	APIDiff bool `json:"apiDiff,omitempty" description:"Recommend the bump from changes to the exported API of the Go module (go.mod) between the last tag and HEAD" default:"false"`
+	// Packages monorepo 中独立打 tag 的包，通过 --package 选择
+	Packages []PackageConfig `json:"packages,omitempty" description:"Packages in a monorepo that are tagged independently, selected with --package"`
+	Hooks    *HooksConfig    `json:"hooks,omitempty" description:"Shell commands run at each step of tag creation. They receive TAGGER_VERSION, TAGGER_PREVIOUS_VERSION, TAGGER_TAG, TAGGER_COMMIT and TAGGER_DRY_RUN"`
+	// VersionFiles 打 tag 前写入新版本号的文件，修改会提交为 chore(release) commit
+	VersionFiles []VersionFile `json:"versionFiles,omitempty" description:"Files whose version is rewritten before tagging. The changes are committed as chore(release): <tag> and the tag points at that commit"`
+	// GoVersionFile 配置后会生成包含版本信息的 Go 源文件，并加入 release commit
+	GoVersionFile *GoVersionFile  `json:"goVersionFile,omitempty" description:"Generate a Go source file with Version, Commit, Date and PreviousVersion constants. It is included in the release commit and regenerated by tagger stamp"`
+	Snapshot      *SnapshotConfig `json:"snapshot,omitempty" description:"Version string printed by tagger snapshot for untagged builds"`
 }
 
-// Load 从当前目录加载配置文件
-func Load() (*Config, error) {
-	configPath := filepath.Join(".", ConfigFileName)
+// DefaultSnapshotTemplate 未配置 snapshot.template 时使用的模板
+const DefaultSnapshotTemplate = "{{.Prefix}}{{.NextPatch}}-SNAPSHOT+g{{.ShortCommit}}"
+
+// DefaultDirtyMark 工作区有未提交的修改时追加的后缀，与 git describe --dirty 一致
+const DefaultDirtyMark = "-dirty"
 
-	// 检查文件是否存在
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, nil // 配置文件不存在，返回 nil
+// SnapshotConfig tagger snapshot 的配置
+type SnapshotConfig struct {
+	Format SnapshotFormat `json:"format,omitempty" description:"go prints a Go pseudo-version, template renders template" default:"go"`
+	// Template 可以使用的字段见 semver.SnapshotData
+	Template  string `json:"template,omitempty" description:"Go text/template for the template format. Available fields: .Prefix, .Current, .NextPatch, .NextMinor, .NextMajor, .Commit, .ShortCommit, .Distance, .Timestamp, .Branch" default:"{{.Prefix}}{{.NextPatch}}-SNAPSHOT+g{{.ShortCommit}}"`
+	DirtyMark string `json:"dirtyMark,omitempty" description:"Appended when tracked files have uncommitted changes, like git describe --dirty" default:"-dirty"`
+}
+
+// GoVersionFile 生成的 Go 版本文件
+type GoVersionFile struct {
+	// Path 通常为 internal/version/version_gen.go
+	Path    string `json:"path" description:"File path relative to the repository root, for example internal/version/version_gen.go" required:"true"`
+	Package string `json:"package,omitempty" description:"Go package name, defaults to the name of the file's directory"`
+}
+
+// PackageName 返回生成文件的包名，未配置时使用所在目录名
+func (f GoVersionFile) PackageName() string {
+	if f.Package != "" {
+		return f.Package
 	}
+	return path.Base(path.Dir(f.Path))
+}
 
-	// 读取文件内容
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, err
+// VersionFile 需要同步版本号的文件
+type VersionFile struct {
+	Path   string            `json:"path" description:"File path relative to the repository root" required:"true"`
+	Format VersionFileFormat `json:"format" description:"How to locate the version: json, yaml or toml use key, regex uses pattern" required:"true"`
+	// Key 以点分隔的路径，例如 package.version
+	Key string `json:"key,omitempty" description:"Dotted path of the version field for json, yaml and toml files" default:"version"`
+	// Pattern 第一个捕获组为版本号，所有匹配都会被替换
+	Pattern string `json:"pattern,omitempty" description:"Regular expression for regex files; the first capture group is replaced with the version in every match"`
+}
+
+// HooksConfig 创建 tag 过程中执行的 shell 命令
+// pre 钩子以非零状态码退出时中止，post 钩子失败只输出警告
+type HooksConfig struct {
+	PreBump  string `json:"preBump,omitempty" description:"Run after the version is chosen, before anything is changed; a non-zero exit aborts"`
+	PreTag   string `json:"preTag,omitempty" description:"Run after preBump, before version files are written, the release commit is made and the tag is created; a non-zero exit aborts"`
+	PostTag  string `json:"postTag,omitempty" description:"Run after the tag is created"`
+	PrePush  string `json:"prePush,omitempty" description:"Run before the tag is pushed; a non-zero exit skips the push"`
+	PostPush string `json:"postPush,omitempty" description:"Run after the tag is pushed"`
+}
+
+// PackageConfig monorepo 中的一个包
+type PackageConfig struct {
+	Name      string `json:"name" description:"Package name used with --package" required:"true"`
+	Path      string `json:"path" description:"Package directory relative to the repository root" required:"true"`
+	TagPrefix string `json:"tagPrefix,omitempty" description:"Prefix of the package's tags, defaults to <path>/v"`
+}
+
+// Prefix 返回包的 tag 前缀，未配置时使用 Go 多模块仓库的约定 <path>/v
+func (p PackageConfig) Prefix() string {
+	if p.TagPrefix != "" {
+		return p.TagPrefix
 	}
+	return strings.TrimSuffix(p.Path, "/") + "/v"
+}
 
-	// 解析 JSON
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
+// Load 加载合并后的配置，没有任何配置来源时返回 nil
+// path 为 --config 指定的配置文件，为空时使用 git 仓库根目录下的 tagger.config.json
+func Load(path string) (*Config, error) {
+	resolved, err := Resolve(path)
+	if err != nil {
 		return nil, err
 	}
-
-	return &config, nil
+	return resolved.Config, nil
 }
 
 // ShouldOpenActionPage 判断是否应该打开 Action 页面
@@ -64,6 +213,81 @@ func (c *Config) ShouldOpenActionPage() bool {
 	return *c.GitHub.OpenActionPage
 }
 
+// RootTagPrefix 返回仓库根目录版本的 tag 前缀，默认为 v
+func (c *Config) RootTagPrefix() string {
+	if c == nil || c.TagPrefix == nil {
+		return DefaultTagPrefix
+	}
+	return *c.TagPrefix
+}
+
+// Scheme 返回版本方案，默认为 semver
+func (c *Config) Scheme() VersionScheme {
+	if c == nil || c.VersionScheme == "" {
+		return SchemeSemVer
+	}
+	return c.VersionScheme
+}
+
+// PushPolicy 返回创建 tag 后的推送方式，默认询问
+func (c *Config) PushPolicy() PushMode {
+	if c == nil || c.Push == "" {
+		return PushAsk
+	}
+	return c.Push
+}
+
+// IncludeChangelog 判断编写 tag message 时是否提供变更日志，默认提供
+func (c *Config) IncludeChangelog() bool {
+	if c == nil || c.Changelog == nil {
+		return true
+	}
+	return *c.Changelog
+}
+
+// AnalyzeAPI 判断是否根据 Go 模块导出 API 的变化建议更新类型，默认不分析
+func (c *Config) AnalyzeAPI() bool {
+	return c != nil && c.APIDiff
+}
+
+// SnapshotSettings 返回 tagger snapshot 的配置，未配置的项使用默认值
+func (c *Config) SnapshotSettings() SnapshotConfig {
+	settings := SnapshotConfig{}
+	if c != nil && c.Snapshot != nil {
+		settings = *c.Snapshot
+	}
+	if settings.Format == "" {
+		settings.Format = SnapshotGo
+	}
+	if settings.Template == "" {
+		settings.Template = DefaultSnapshotTemplate
+	}
+	if settings.DirtyMark == "" {
+		settings.DirtyMark = DefaultDirtyMark
+	}
+	return settings
+}
+
+// HookCommands 返回配置的钩子，未配置时返回空值
+func (c *Config) HookCommands() HooksConfig {
+	if c == nil || c.Hooks == nil {
+		return HooksConfig{}
+	}
+	return *c.Hooks
+}
+
+// FindPackage 按名称查找 packages 中的包
+func (c *Config) FindPackage(name string) (*PackageConfig, error) {
+	if c != nil {
+		for i := range c.Packages {
+			if c.Packages[i].Name == name {
+				return &c.Packages[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("package %q is not configured (add it to packages or run tagger init)", name)
+}
+
 // IsGitHub 判断配置中的托管平台是否为 GitHub
 func (c *Config) IsGitHub() bool {
 	if c == nil {
@@ -72,34 +296,30 @@ func (c *Config) IsGitHub() bool {
 	return c.GitHostingProvider == GitHub
 }
 
-// CreateDefault 创建默认配置文件
-func CreateDefault() error {
-	// 检查文件是否已存在
-	configPath := filepath.Join(".", ConfigFileName)
-	if _, err := os.Stat(configPath); err == nil {
-		return fmt.Errorf("config file already exists: %s", configPath)
+// Create 写入项目配置文件，返回写入的路径
+// 已存在配置文件时返回错误；force 为 true 时按原有格式覆盖该文件
+func Create(cfg *Config, force bool) (string, error) {
+	existing, err := FindProjectConfig()
+	if err != nil {
+		return "", err
 	}
 
-	// 创建默认配置
-	openActionPage := true
-	config := Config{
-		Schema:             SchemaURL,
-		GitHostingProvider: GitHub,
-		GitHub: &GitHubConfig{
-			OpenActionPage: &openActionPage,
-		},
+	path := ProjectConfigPath()
+	if existing != "" {
+		if !force {
+			return "", fmt.Errorf("config file already exists: %s (use --force to overwrite)", existing)
+		}
+		path = existing
 	}
 
-	// 序列化为 JSON（带缩进）
-	data, err := json.MarshalIndent(config, "", "  ")
+	data, err := encodeConfigFile(FormatOf(path), cfg)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return "", err
 	}
 
-	// 写入文件
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write config file: %w", err)
 	}
 
-	return nil
+	return path, nil
 }
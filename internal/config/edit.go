@@ -0,0 +1,490 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document 一个可编辑的配置文件，修改时保留已有的键顺序
+type document interface {
+	// config 返回配置所在的节点，用于校验
+	config() (*Node, error)
+	set(path []string, value *Node)
+	unset(path []string) bool
+	encode() ([]byte, error)
+}
+
+// SetValue 将点分隔的 key 设置为 raw，raw 按 schema 中的类型解析
+// 数组和对象使用 JSON 表示，例如 '[{"name":"api","path":"api"}]'
+// 文件不存在时创建一个带有 $schema 的 JSON 配置文件
+func SetValue(path, key, raw string) error {
+	schema, err := LoadSchema()
+	if err != nil {
+		return err
+	}
+
+	sub, ok := schema.Lookup(key)
+	if !ok || key == "$schema" {
+		return unknownKeyError(schema, key)
+	}
+
+	value, err := parseValue(sub, key, raw)
+	if err != nil {
+		return err
+	}
+
+	doc, err := openDocument(path)
+	if err != nil {
+		return err
+	}
+	doc.set(strings.Split(key, "."), value)
+
+	return saveDocument(path, doc, schema)
+}
+
+// UnsetValue 删除点分隔的 key，删除后为空的父对象也会被删除
+// 返回 false 表示该 key 没有设置
+func UnsetValue(path, key string) (bool, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	schema, err := LoadSchema()
+	if err != nil {
+		return false, err
+	}
+
+	doc, err := openDocument(path)
+	if err != nil {
+		return false, err
+	}
+	if !doc.unset(strings.Split(key, ".")) {
+		return false, nil
+	}
+
+	return true, saveDocument(path, doc, schema)
+}
+
+// FileValue 返回单个配置文件中点分隔 key 的值
+func FileValue(path, key string) (any, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	node, err := parseConfigFile(path, data)
+	if err != nil || node == nil {
+		return nil, false, err
+	}
+
+	for _, part := range strings.Split(key, ".") {
+		member, ok := node.Get(part)
+		if !ok {
+			return nil, false, nil
+		}
+		node = member.Value
+	}
+	return node.Interface(), true, nil
+}
+
+// Lookup 返回合并后配置中点分隔 key 的值，key 可以指向对象，例如 github
+func (r *Resolved) Lookup(key string) (any, bool) {
+	if value, ok := r.Values[key]; ok {
+		return value, true
+	}
+
+	var current any = r.Nested()
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// unknownKeyError 返回未知 key 的错误，并给出最接近的合法 key
+func unknownKeyError(schema *Schema, key string) error {
+	keys := schemaKeys(schema, "")
+	if match := closest(key, keys); match != "" {
+		return fmt.Errorf("unknown config key %q (did you mean %q?)", key, match)
+	}
+	return fmt.Errorf("unknown config key %q", key)
+}
+
+// schemaKeys 列出 schema 中所有可设置的点分隔 key
+func schemaKeys(s *Schema, prefix string) []string {
+	var keys []string
+	for name, prop := range s.Properties {
+		if name == "$schema" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		keys = append(keys, path)
+		if prop.Type == "object" {
+			keys = append(keys, schemaKeys(prop, path)...)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseValue 按 schema 中的类型将命令行参数解析为节点
+func parseValue(s *Schema, key, raw string) (*Node, error) {
+	switch s.Type {
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected boolean, got %q", key, raw)
+		}
+		return &Node{Kind: BoolNode, Value: b}, nil
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, fmt.Errorf("%s: expected %s, got %q", key, s.Type, raw)
+		}
+		return &Node{Kind: NumberNode, Value: json.Number(raw)}, nil
+	case "array", "object":
+		node, err := ParseNode([]byte(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected a JSON %s: %w", key, s.Type, err)
+		}
+		// 位置来自命令行参数而不是配置文件，不应出现在错误信息中
+		clearPositions(node)
+		return node, nil
+	default:
+		return &Node{Kind: StringNode, Value: raw}, nil
+	}
+}
+
+func clearPositions(node *Node) {
+	node.Line, node.Column = 0, 0
+	for i := range node.Members {
+		node.Members[i].Line, node.Members[i].Column = 0, 0
+		clearPositions(node.Members[i].Value)
+	}
+	for _, item := range node.Items {
+		clearPositions(item)
+	}
+}
+
+// openDocument 打开配置文件用于编辑，JSON 文件不存在时创建新的文档
+func openDocument(path string) (document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	exists := err == nil
+
+	switch FormatOf(path) {
+	case FormatTOML:
+		return nil, fmt.Errorf("%s: editing TOML config files is not supported, edit the file manually", path)
+	case FormatYAML:
+		var doc yaml.Node
+		if exists {
+			if err := yaml.Unmarshal(data, &doc); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+		return &yamlDocument{doc: &doc}, nil
+	case FormatPackageJSON:
+		if !exists {
+			return nil, fmt.Errorf("%s does not exist", path)
+		}
+		root, err := ParseNode(data)
+		if err != nil {
+			return nil, fileError(path, err)
+		}
+		return &jsonDocument{root: root, prefix: []string{packageJSONKey}}, nil
+	default:
+		if !exists {
+			root := &Node{Kind: ObjectNode}
+			setNode(root, []string{"$schema"}, &Node{Kind: StringNode, Value: SchemaURL})
+			return &jsonDocument{root: root}, nil
+		}
+		root, err := ParseNode(data)
+		if err != nil {
+			return nil, fileError(path, err)
+		}
+		return &jsonDocument{root: root}, nil
+	}
+}
+
+// saveDocument 校验修改后的配置并写回文件
+func saveDocument(path string, doc document, schema *Schema) error {
+	node, err := doc.config()
+	if err != nil {
+		return err
+	}
+	if node != nil {
+		if problems := schema.Validate(node, path); len(problems) > 0 {
+			return problems
+		}
+	}
+
+	data, err := doc.encode()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// jsonDocument JSON 配置文件；prefix 为配置所在的路径，例如 package.json 中的 tagger
+type jsonDocument struct {
+	root   *Node
+	prefix []string
+}
+
+func (d *jsonDocument) config() (*Node, error) {
+	node := d.root
+	for _, part := range d.prefix {
+		member, ok := node.Get(part)
+		if !ok {
+			return nil, nil
+		}
+		node = member.Value
+	}
+	return node, nil
+}
+
+func (d *jsonDocument) set(path []string, value *Node) {
+	setNode(d.root, append(append([]string{}, d.prefix...), path...), value)
+}
+
+func (d *jsonDocument) unset(path []string) bool {
+	node, _ := d.config()
+	if node == nil {
+		return false
+	}
+	return unsetNode(node, path)
+}
+
+func (d *jsonDocument) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, d.root, ""); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// setNode 设置节点中的路径，缺少的父对象会被创建
+func setNode(node *Node, path []string, value *Node) {
+	for _, part := range path[:len(path)-1] {
+		member, ok := node.Get(part)
+		if !ok {
+			node.Members = append(node.Members, Member{Key: part, Value: &Node{Kind: ObjectNode}})
+			member = &node.Members[len(node.Members)-1]
+		}
+		if member.Value.Kind != ObjectNode {
+			member.Value = &Node{Kind: ObjectNode}
+		}
+		node = member.Value
+	}
+
+	last := path[len(path)-1]
+	if member, ok := node.Get(last); ok {
+		member.Value = value
+		return
+	}
+	node.Members = append(node.Members, Member{Key: last, Value: value})
+}
+
+// unsetNode 删除节点中的路径，删除后为空的父对象也会被删除
+func unsetNode(node *Node, path []string) bool {
+	for i, member := range node.Members {
+		if member.Key != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			node.Members = append(node.Members[:i], node.Members[i+1:]...)
+			return true
+		}
+
+		if member.Value.Kind != ObjectNode || !unsetNode(member.Value, path[1:]) {
+			return false
+		}
+		if len(member.Value.Members) == 0 {
+			node.Members = append(node.Members[:i], node.Members[i+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// writeJSON 按成员顺序以两个空格缩进输出节点
+func writeJSON(buf *bytes.Buffer, node *Node, indent string) error {
+	switch node.Kind {
+	case ObjectNode:
+		if len(node.Members) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i, member := range node.Members {
+			buf.WriteString(indent + "  ")
+			if err := writeJSONScalar(buf, member.Key); err != nil {
+				return err
+			}
+			buf.WriteString(": ")
+			if err := writeJSON(buf, member.Value, indent+"  "); err != nil {
+				return err
+			}
+			if i < len(node.Members)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "}")
+	case ArrayNode:
+		if len(node.Items) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, item := range node.Items {
+			buf.WriteString(indent + "  ")
+			if err := writeJSON(buf, item, indent+"  "); err != nil {
+				return err
+			}
+			if i < len(node.Items)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "]")
+	case NumberNode:
+		buf.WriteString(string(node.Value.(json.Number)))
+	case NullNode:
+		buf.WriteString("null")
+	default:
+		return writeJSONScalar(buf, node.Value)
+	}
+	return nil
+}
+
+func writeJSONScalar(buf *bytes.Buffer, value any) error {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	buf.Write(bytes.TrimRight(out.Bytes(), "\n"))
+	return nil
+}
+
+// yamlDocument YAML 配置文件，直接编辑 yaml.Node 以保留注释和键顺序
+type yamlDocument struct {
+	doc *yaml.Node
+}
+
+// mapping 返回文档的顶层对象，空文档时创建
+func (d *yamlDocument) mapping() *yaml.Node {
+	if d.doc.Kind != yaml.DocumentNode {
+		d.doc.Kind = yaml.DocumentNode
+	}
+	if len(d.doc.Content) == 0 {
+		d.doc.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+	return d.doc.Content[0]
+}
+
+func (d *yamlDocument) config() (*Node, error) {
+	return convertYAML(d.mapping())
+}
+
+func (d *yamlDocument) set(path []string, value *Node) {
+	node := d.mapping()
+	for _, part := range path[:len(path)-1] {
+		child := yamlGet(node, part)
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			yamlPut(node, part, child)
+		}
+		node = child
+	}
+	yamlPut(node, path[len(path)-1], yamlNode(value))
+}
+
+func (d *yamlDocument) unset(path []string) bool {
+	return yamlUnset(d.mapping(), path)
+}
+
+func (d *yamlDocument) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(d.doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlGet 返回 mapping 中 key 对应的值
+func yamlGet(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlPut 设置 mapping 中 key 对应的值，key 已存在时保留其位置和注释
+func yamlPut(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+func yamlUnset(mapping *yaml.Node, path []string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return true
+		}
+
+		child := mapping.Content[i+1]
+		if child.Kind != yaml.MappingNode || !yamlUnset(child, path[1:]) {
+			return false
+		}
+		if len(child.Content) == 0 {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// PackageJSONFileName 可以在其中的 tagger 字段下配置 tagger
+const PackageJSONFileName = "package.json"
+
+// packageJSONKey package.json 中 tagger 配置所在的字段
+const packageJSONKey = "tagger"
+
+// Format 配置文件格式
+type Format string
+
+const (
+	FormatJSON        Format = "json"
+	FormatYAML        Format = "yaml"
+	FormatTOML        Format = "toml"
+	FormatPackageJSON Format = "package.json"
+)
+
+// projectConfigFileNames 项目配置文件的候选文件名，同一目录下最多只能存在一个
+var projectConfigFileNames = []string{
+	ConfigFileName,
+	"tagger.config.yaml",
+	"tagger.config.yml",
+	"tagger.config.toml",
+	PackageJSONFileName,
+}
+
+// userConfigFileNames 用户级配置文件的候选文件名
+var userConfigFileNames = []string{
+	"config.json",
+	"config.yaml",
+	"config.yml",
+	"config.toml",
+}
+
+// FormatOf 根据文件名判断配置文件格式
+func FormatOf(path string) Format {
+	if filepath.Base(path) == PackageJSONFileName {
+		return FormatPackageJSON
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// findConfigFile 在 dir 中查找候选配置文件，不存在时返回空字符串，存在多个时返回错误
+func findConfigFile(dir string, names []string) (string, error) {
+	var found []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		// 没有 tagger 字段的 package.json 不算配置文件
+		if FormatOf(path) == FormatPackageJSON {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("failed to read config file: %w", err)
+			}
+			// 无法解析的 package.json 只在其中出现 tagger 字段时报错，以免影响没有使用它配置 tagger 的项目
+			node, err := ParseNode(data)
+			if err != nil {
+				if !bytes.Contains(data, []byte(`"`+packageJSONKey+`"`)) {
+					continue
+				}
+				return "", fileError(path, err)
+			}
+			if _, ok := node.Get(packageJSONKey); !ok {
+				continue
+			}
+		}
+
+		found = append(found, path)
+	}
+
+	switch len(found) {
+	case 0:
+		return "", nil
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("multiple config files found: %s; keep only one", strings.Join(found, ", "))
+	}
+}
+
+// parseConfigFile 按文件格式解析配置，得到带位置信息的节点
+// package.json 中没有 tagger 字段时返回 nil
+func parseConfigFile(path string, data []byte) (*Node, error) {
+	var (
+		node *Node
+		err  error
+	)
+
+	switch FormatOf(path) {
+	case FormatYAML:
+		node, err = parseYAML(data)
+	case FormatTOML:
+		node, err = parseTOML(data)
+	case FormatPackageJSON:
+		node, err = ParseNode(data)
+		if err == nil {
+			member, ok := node.Get(packageJSONKey)
+			if !ok {
+				return nil, nil
+			}
+			node = member.Value
+		}
+	default:
+		node, err = ParseNode(data)
+	}
+
+	if err != nil {
+		return nil, fileError(path, err)
+	}
+	return node, nil
+}
+
+// fileError 为解析错误加上文件名，有位置信息时使用 path:line:col: 的形式
+func fileError(path string, err error) error {
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Line > 0 {
+		return fmt.Errorf("%s:%w", path, err)
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// parseYAML 解析 YAML 文本，保留 yaml.v3 提供的行列号
+func parseYAML(data []byte) (*Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &SyntaxError{Err: err}
+	}
+
+	// 空文件视为空对象
+	if len(doc.Content) == 0 {
+		return &Node{Kind: ObjectNode, Line: 1, Column: 1}, nil
+	}
+	return convertYAML(doc.Content[0])
+}
+
+func convertYAML(n *yaml.Node) (*Node, error) {
+	if n.Kind == yaml.AliasNode {
+		return convertYAML(n.Alias)
+	}
+
+	node := &Node{Line: n.Line, Column: n.Column}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		node.Kind = ObjectNode
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			child, err := convertYAML(value)
+			if err != nil {
+				return nil, err
+			}
+			node.Members = append(node.Members, Member{Key: key.Value, Line: key.Line, Column: key.Column, Value: child})
+		}
+	case yaml.SequenceNode:
+		node.Kind = ArrayNode
+		for _, item := range n.Content {
+			child, err := convertYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Items = append(node.Items, child)
+		}
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null":
+			node.Kind = NullNode
+		case "!!bool":
+			var b bool
+			if err := n.Decode(&b); err != nil {
+				return nil, &SyntaxError{Line: n.Line, Column: n.Column, Err: err}
+			}
+			node.Kind, node.Value = BoolNode, b
+		case "!!int":
+			var i int64
+			if err := n.Decode(&i); err != nil {
+				return nil, &SyntaxError{Line: n.Line, Column: n.Column, Err: err}
+			}
+			node.Kind, node.Value = NumberNode, json.Number(strconv.FormatInt(i, 10))
+		case "!!float":
+			var f float64
+			if err := n.Decode(&f); err != nil {
+				return nil, &SyntaxError{Line: n.Line, Column: n.Column, Err: err}
+			}
+			node.Kind, node.Value = NumberNode, json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+		default:
+			node.Kind, node.Value = StringNode, n.Value
+		}
+	default:
+		return nil, &SyntaxError{Line: n.Line, Column: n.Column, Err: fmt.Errorf("unsupported YAML node")}
+	}
+
+	return node, nil
+}
+
+// parseTOML 解析 TOML 文本，TOML 解析器不提供键的位置，只有语法错误带有行列号
+func parseTOML(data []byte) (*Node, error) {
+	var raw map[string]any
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &SyntaxError{Line: parseErr.Position.Line, Column: parseErr.Position.Col, Err: errors.New(parseErr.Message)}
+		}
+		return nil, &SyntaxError{Err: err}
+	}
+	return valueNode(raw), nil
+}
+
+// valueNode 将解码得到的 Go 值转换为没有位置信息的节点
+func valueNode(value any) *Node {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		node := &Node{Kind: ObjectNode}
+		for _, key := range keys {
+			node.Members = append(node.Members, Member{Key: key, Value: valueNode(v[key])})
+		}
+		return node
+	case []map[string]any:
+		node := &Node{Kind: ArrayNode}
+		for _, item := range v {
+			node.Items = append(node.Items, valueNode(item))
+		}
+		return node
+	case []any:
+		node := &Node{Kind: ArrayNode}
+		for _, item := range v {
+			node.Items = append(node.Items, valueNode(item))
+		}
+		return node
+	case bool:
+		return &Node{Kind: BoolNode, Value: v}
+	case int64:
+		return &Node{Kind: NumberNode, Value: json.Number(strconv.FormatInt(v, 10))}
+	case float64:
+		return &Node{Kind: NumberNode, Value: json.Number(strconv.FormatFloat(v, 'f', -1, 64))}
+	case string:
+		return &Node{Kind: StringNode, Value: v}
+	case time.Time:
+		return &Node{Kind: StringNode, Value: v.Format(time.RFC3339)}
+	case nil:
+		return &Node{Kind: NullNode}
+	default:
+		return &Node{Kind: StringNode, Value: fmt.Sprint(v)}
+	}
+}
+
+// encodeConfigFile 按文件格式序列化配置，保留结构体中的字段顺序
+func encodeConfigFile(format Format, cfg *Config) ([]byte, error) {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	switch format {
+	case FormatYAML:
+		node, err := ParseNode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		return yaml.Marshal(yamlNode(node))
+	case FormatTOML:
+		var raw map[string]any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		return buf.Bytes(), nil
+	case FormatPackageJSON:
+		return nil, fmt.Errorf("cannot overwrite the %q key in %s, edit it manually", packageJSONKey, PackageJSONFileName)
+	default:
+		return append(data, '\n'), nil
+	}
+}
+
+// yamlNode 将节点转换为 yaml.Node，保留对象成员的顺序
+func yamlNode(n *Node) *yaml.Node {
+	switch n.Kind {
+	case ObjectNode:
+		out := &yaml.Node{Kind: yaml.MappingNode}
+		for _, member := range n.Members {
+			out.Content = append(out.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: member.Key},
+				yamlNode(member.Value))
+		}
+		return out
+	case ArrayNode:
+		out := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range n.Items {
+			out.Content = append(out.Content, yamlNode(item))
+		}
+		return out
+	case BoolNode:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(n.Value.(bool))}
+	case NumberNode:
+		tag := "!!int"
+		if strings.ContainsAny(string(n.Value.(json.Number)), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(n.Value.(json.Number))}
+	case StringNode:
+		out := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: n.Value.(string)}
+		if strings.Contains(out.Value, "\n") {
+			out.Style = yaml.LiteralStyle
+		}
+		return out
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
@@ -0,0 +1,480 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/AkaraChen/tagger/internal/git"
+)
+
+// EnvPrefix 环境变量覆盖的前缀，例如 TAGGER_GITHUB_OPEN_ACTION_PAGE
+const EnvPrefix = "TAGGER_"
+
+// SourceKind 配置值的来源类型
+type SourceKind string
+
+// 配置来源，按优先级从低到高排列
+const (
+	SourceDefault SourceKind = "default"
+	SourceUser    SourceKind = "user"
+	SourceProject SourceKind = "project"
+	SourceEnv     SourceKind = "env"
+)
+
+// Source 表示一个配置值的来源
+type Source struct {
+	Kind SourceKind
+	// Name 为配置文件路径或环境变量名，默认值时为空
+	Name string
+}
+
+func (s Source) String() string {
+	if s.Name == "" {
+		return string(s.Kind)
+	}
+	return fmt.Sprintf("%s (%s)", s.Kind, s.Name)
+}
+
+// Resolved 合并所有来源后的配置
+type Resolved struct {
+	// Config 合并后的配置，没有任何来源时为 nil
+	Config *Config
+	// Values 每个生效配置项（点分隔的 key）的值，包含默认值
+	Values map[string]any
+	// Origins 每个生效配置项的来源
+	Origins map[string]Source
+	// Files 实际读取的配置文件，按优先级从低到高排列
+	Files []string
+}
+
+// Keys 返回所有生效配置项的 key，按字母顺序排列
+func (r *Resolved) Keys() []string {
+	keys := make([]string, 0, len(r.Values))
+	for key := range r.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Nested 返回所有生效配置项组成的嵌套对象
+func (r *Resolved) Nested() map[string]any {
+	return unflatten(r.Values)
+}
+
+// layer 一个配置来源解析后的扁平数据
+type layer struct {
+	values  map[string]any
+	origins map[string]Source
+}
+
+func newLayer() layer {
+	return layer{values: make(map[string]any), origins: make(map[string]Source)}
+}
+
+func (l layer) set(key string, value any, source Source) {
+	l.values[key] = value
+	l.origins[key] = source
+}
+
+// UserConfigDir 返回用户级配置目录：$XDG_CONFIG_HOME/tagger，
+// 未设置 XDG_CONFIG_HOME 时使用 ~/.config/tagger
+func UserConfigDir() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "tagger"), nil
+}
+
+// UserConfigPath 返回用户级配置文件路径，支持 config.json、config.yaml、config.yml 和 config.toml，
+// 都不存在时返回 config.json
+func UserConfigPath() (string, error) {
+	dir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	path, err := findConfigFile(dir, userConfigFileNames)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		path = filepath.Join(dir, userConfigFileNames[0])
+	}
+	return path, nil
+}
+
+// ProjectRoot 返回项目根目录，即 git 仓库根目录；不在仓库中时使用当前目录
+func ProjectRoot() string {
+	root, err := git.NewGitClient(".").GetTopLevel()
+	if err != nil {
+		return "."
+	}
+	return root
+}
+
+// ProjectConfigPath 返回新建项目配置文件时使用的路径
+func ProjectConfigPath() string {
+	return filepath.Join(ProjectRoot(), ConfigFileName)
+}
+
+// FindProjectConfig 在项目根目录中查找配置文件：tagger.config.json、tagger.config.yaml、
+// tagger.config.yml、tagger.config.toml 或 package.json 中的 tagger 字段
+// 不存在时返回空字符串，存在多个时返回错误
+func FindProjectConfig() (string, error) {
+	return findConfigFile(ProjectRoot(), projectConfigFileNames)
+}
+
+// Resolve 按优先级合并配置：用户级配置 < 项目配置 < TAGGER_* 环境变量
+// path 不为空时（--config）使用该文件代替项目配置，且文件必须存在
+func Resolve(path string) (*Resolved, error) {
+	var layers []layer
+	var files []string
+
+	// 校验错误在所有来源中收集后一起返回
+	var problems ValidationErrors
+	collect := func(err error) error {
+		var verrs ValidationErrors
+		if errors.As(err, &verrs) {
+			problems = append(problems, verrs...)
+			return nil
+		}
+		return err
+	}
+
+	// 1. 用户级配置
+	userPath, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	userLayer, err := loadFileLayer(userPath, SourceUser, false)
+	if err := collect(err); err != nil {
+		return nil, err
+	}
+	if userLayer != nil {
+		layers = append(layers, *userLayer)
+		files = append(files, userPath)
+	}
+
+	// 2. 项目配置（或 --config 指定的文件）
+	projectPath := path
+	if projectPath == "" {
+		projectPath, err = FindProjectConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var projectLayer *layer
+	if projectPath != "" {
+		projectLayer, err = loadFileLayer(projectPath, SourceProject, path != "")
+		if err := collect(err); err != nil {
+			return nil, err
+		}
+	}
+	if projectLayer != nil {
+		layers = append(layers, *projectLayer)
+		files = append(files, projectPath)
+	}
+
+	// 3. 环境变量
+	envLayer, err := loadEnvLayer()
+	if err := collect(err); err != nil {
+		return nil, err
+	}
+	if len(envLayer.values) > 0 {
+		layers = append(layers, envLayer)
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	// 4. 合并，后面的来源覆盖前面的来源
+	resolved := &Resolved{
+		Values:  make(map[string]any),
+		Origins: make(map[string]Source),
+		Files:   files,
+	}
+
+	for _, l := range layers {
+		for key, value := range l.values {
+			resolved.Values[key] = value
+			resolved.Origins[key] = l.origins[key]
+		}
+	}
+
+	if len(layers) > 0 {
+		cfg, err := decode(resolved.Values)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Config = cfg
+	}
+
+	// 默认值只用于展示来源，不写入 Config，以保持"未配置"的语义
+	for key, value := range defaults() {
+		if _, ok := resolved.Values[key]; !ok {
+			resolved.Values[key] = value
+			resolved.Origins[key] = Source{Kind: SourceDefault}
+		}
+	}
+
+	return resolved, nil
+}
+
+// defaults 返回 schema 中声明了默认值的配置项
+func defaults() map[string]any {
+	values := make(map[string]any)
+	collectDefaults(GenerateSchema(), "", values)
+	return values
+}
+
+func collectDefaults(s *Schema, prefix string, out map[string]any) {
+	for name, prop := range s.Properties {
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		if prop.Default != nil {
+			out[path] = prop.Default
+		}
+		if prop.Type == "object" {
+			collectDefaults(prop, path, out)
+		}
+	}
+}
+
+// loadFileLayer 读取并校验一个配置文件，文件不存在且不是必需时返回 nil
+func loadFileLayer(path string, kind SourceKind, required bool) (*layer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	node, err := parseConfigFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		if required {
+			return nil, fmt.Errorf("%s: no %q key found", path, packageJSONKey)
+		}
+		return nil, nil
+	}
+
+	schema, err := LoadSchema()
+	if err != nil {
+		return nil, err
+	}
+	if problems := schema.Validate(node, path); len(problems) > 0 {
+		return nil, problems
+	}
+
+	raw, _ := node.Interface().(map[string]any)
+	values := make(map[string]any)
+	flatten("", raw, values)
+	delete(values, "$schema")
+
+	l := newLayer()
+	for key, value := range values {
+		l.set(key, value, Source{Kind: kind, Name: path})
+	}
+	return &l, nil
+}
+
+// loadEnvLayer 读取并校验 TAGGER_* 环境变量，只支持标量配置项
+func loadEnvLayer() (layer, error) {
+	l := newLayer()
+
+	schema, err := LoadSchema()
+	if err != nil {
+		return l, err
+	}
+
+	var problems ValidationErrors
+	for _, f := range scalarFields() {
+		raw, ok := os.LookupEnv(f.env)
+		if !ok {
+			continue
+		}
+
+		value, err := parseScalar(f.kind, raw)
+		if err != nil {
+			problems = append(problems, ValidationError{
+				File:    f.env,
+				Pointer: "/" + strings.ReplaceAll(f.path, ".", "/"),
+				Message: fmt.Sprintf("invalid %s value %q", f.kind, raw),
+			})
+			continue
+		}
+
+		if sub, ok := schema.Lookup(f.path); ok {
+			node := &Node{Kind: scalarKind(f.kind), Value: value}
+			if f.kind == reflect.Int {
+				node.Value = json.Number(raw)
+			}
+			for _, problem := range sub.Validate(node, f.env) {
+				problem.Pointer = "/" + strings.ReplaceAll(f.path, ".", "/")
+				problems = append(problems, problem)
+			}
+		}
+
+		l.set(f.path, value, Source{Kind: SourceEnv, Name: f.env})
+	}
+
+	if len(problems) > 0 {
+		return l, problems
+	}
+	return l, nil
+}
+
+func scalarKind(kind reflect.Kind) NodeKind {
+	switch kind {
+	case reflect.Bool:
+		return BoolNode
+	case reflect.Int:
+		return NumberNode
+	default:
+		return StringNode
+	}
+}
+
+// flatten 将嵌套对象展开为点分隔的 key，数组和标量作为叶子节点
+func flatten(prefix string, value map[string]any, out map[string]any) {
+	for key, v := range value {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := v.(map[string]any); ok {
+			flatten(path, nested, out)
+			continue
+		}
+		out[path] = v
+	}
+}
+
+// unflatten 将点分隔的 key 还原为嵌套对象
+func unflatten(values map[string]any) map[string]any {
+	root := make(map[string]any)
+
+	for path, value := range values {
+		parts := strings.Split(path, ".")
+		node := root
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+
+	return root
+}
+
+// decode 将扁平的配置值转换为 Config
+func decode(values map[string]any) (*Config, error) {
+	data, err := json.Marshal(unflatten(values))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to merge config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// scalarField 可以通过环境变量覆盖的配置项
+type scalarField struct {
+	path string
+	env  string
+	kind reflect.Kind
+}
+
+// scalarFields 通过反射列出 Config 中所有标量配置项
+func scalarFields() []scalarField {
+	var fields []scalarField
+	collectScalarFields(reflect.TypeOf(Config{}), "", &fields)
+	return fields
+}
+
+func collectScalarFields(t reflect.Type, prefix string, out *[]scalarField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "$schema" {
+			continue
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		switch ft.Kind() {
+		case reflect.Struct:
+			collectScalarFields(ft, path, out)
+		case reflect.String, reflect.Bool, reflect.Int:
+			*out = append(*out, scalarField{path: path, env: envName(path), kind: ft.Kind()})
+		}
+	}
+}
+
+// envName 将配置 key 转换为环境变量名，例如 github.openActionPage -> TAGGER_GITHUB_OPEN_ACTION_PAGE
+func envName(path string) string {
+	var sb strings.Builder
+	sb.WriteString(EnvPrefix)
+
+	for i, r := range path {
+		switch {
+		case r == '.':
+			sb.WriteRune('_')
+		case unicode.IsUpper(r) && i > 0 && path[i-1] != '.':
+			sb.WriteRune('_')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	return sb.String()
+}
+
+func parseScalar(kind reflect.Kind, raw string) (any, error) {
+	switch kind {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int:
+		return strconv.Atoi(raw)
+	default:
+		return raw, nil
+	}
+}
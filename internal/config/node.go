@@ -0,0 +1,273 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// NodeKind JSON 节点的类型
+type NodeKind int
+
+const (
+	NullNode NodeKind = iota
+	BoolNode
+	NumberNode
+	StringNode
+	ArrayNode
+	ObjectNode
+)
+
+// String 返回 JSON Schema 中对应的类型名
+func (k NodeKind) String() string {
+	switch k {
+	case BoolNode:
+		return "boolean"
+	case NumberNode:
+		return "number"
+	case StringNode:
+		return "string"
+	case ArrayNode:
+		return "array"
+	case ObjectNode:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+// Node 带有位置信息的 JSON 节点，用于报告精确的校验错误
+type Node struct {
+	Kind    NodeKind
+	Value   any // 标量节点的值：bool、json.Number 或 string
+	Members []Member
+	Items   []*Node
+	// Line 和 Column 从 1 开始，为 0 时表示没有位置信息（例如来自环境变量）
+	Line   int
+	Column int
+}
+
+// Member 对象中的一个键值对，保留原始顺序
+type Member struct {
+	Key    string
+	Line   int
+	Column int
+	Value  *Node
+}
+
+// Get 返回对象中指定 key 的成员
+func (n *Node) Get(key string) (*Member, bool) {
+	if n == nil || n.Kind != ObjectNode {
+		return nil, false
+	}
+	for i := range n.Members {
+		if n.Members[i].Key == key {
+			return &n.Members[i], true
+		}
+	}
+	return nil, false
+}
+
+// Interface 将节点转换为 encoding/json 解码得到的普通 Go 值
+func (n *Node) Interface() any {
+	switch n.Kind {
+	case ObjectNode:
+		m := make(map[string]any, len(n.Members))
+		for _, member := range n.Members {
+			m[member.Key] = member.Value.Interface()
+		}
+		return m
+	case ArrayNode:
+		items := make([]any, 0, len(n.Items))
+		for _, item := range n.Items {
+			items = append(items, item.Interface())
+		}
+		return items
+	case NumberNode:
+		f, _ := n.Value.(json.Number).Float64()
+		return f
+	default:
+		return n.Value
+	}
+}
+
+// SyntaxError 带有行列号的 JSON 语法错误
+type SyntaxError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+// Error 没有位置信息（Line 为 0）时只返回原始错误
+func (e *SyntaxError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%d:%d: %v", e.Line, e.Column, e.Err)
+}
+
+// ParseNode 解析 JSON 文本，记录每个键和值的位置
+func ParseNode(data []byte) (*Node, error) {
+	p := &nodeParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+
+	node, err := p.parseValue()
+	if err != nil {
+		return nil, p.wrap(err)
+	}
+
+	// 确保没有多余的内容
+	if _, err := p.dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("unexpected content after top-level value")
+		}
+		return nil, p.wrap(err)
+	}
+
+	return node, nil
+}
+
+// nodeParser 基于 json.Decoder 的 token 流构建 Node 树
+type nodeParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// next 读取下一个 token，并返回其起始位置
+func (p *nodeParser) next() (json.Token, int, int, error) {
+	// InputOffset 指向上一个 token 的末尾，需要跳过空白和分隔符
+	offset := int(p.dec.InputOffset())
+	for offset < len(p.data) {
+		c := p.data[offset]
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' && c != ':' {
+			break
+		}
+		offset++
+	}
+
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	line, column := position(p.data, offset)
+	return tok, line, column, nil
+}
+
+func (p *nodeParser) parseValue() (*Node, error) {
+	tok, line, column, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseToken(tok, line, column)
+}
+
+func (p *nodeParser) parseToken(tok json.Token, line, column int) (*Node, error) {
+	node := &Node{Line: line, Column: column}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			node.Kind = ObjectNode
+			return node, p.parseObject(node)
+		case '[':
+			node.Kind = ArrayNode
+			return node, p.parseArray(node)
+		default:
+			return nil, fmt.Errorf("unexpected %q", rune(v))
+		}
+	case bool:
+		node.Kind = BoolNode
+	case json.Number:
+		node.Kind = NumberNode
+	case string:
+		node.Kind = StringNode
+	case nil:
+		node.Kind = NullNode
+	}
+
+	node.Value = tok
+	return node, nil
+}
+
+func (p *nodeParser) parseObject(node *Node) error {
+	for {
+		tok, line, column, err := p.next()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim('}') {
+			return nil
+		}
+
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return err
+		}
+
+		node.Members = append(node.Members, Member{Key: key, Line: line, Column: column, Value: value})
+	}
+}
+
+func (p *nodeParser) parseArray(node *Node) error {
+	for {
+		tok, line, column, err := p.next()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim(']') {
+			return nil
+		}
+
+		item, err := p.parseToken(tok, line, column)
+		if err != nil {
+			return err
+		}
+		node.Items = append(node.Items, item)
+	}
+}
+
+// wrap 为错误附加行列号
+func (p *nodeParser) wrap(err error) error {
+	offset := int(p.dec.InputOffset())
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = int(syntaxErr.Offset)
+	}
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = errors.New("unexpected end of JSON input")
+		offset = len(p.data)
+	}
+
+	line, column := position(p.data, offset)
+	return &SyntaxError{Line: line, Column: column, Err: err}
+}
+
+// position 将字节偏移量转换为从 1 开始的行列号
+func position(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	line, column := 1, 1
+	for _, c := range data[:offset] {
+		switch {
+		case c == '\n':
+			line++
+			column = 1
+		case c&0xC0 != 0x80:
+			// 按字符而不是字节计算列号，跳过 UTF-8 的后续字节
+			column++
+		}
+	}
+	return line, column
+}
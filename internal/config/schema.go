@@ -0,0 +1,267 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Schema JSON Schema 中 tagger 用到的子集
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+var (
+	schemaOnce sync.Once
+	schema     *Schema
+)
+
+// LoadSchema 返回根据 Config 类型生成的配置 schema
+func LoadSchema() (*Schema, error) {
+	schemaOnce.Do(func() {
+		schema = GenerateSchema()
+	})
+	return schema, nil
+}
+
+// ValidationError 一个配置校验错误
+type ValidationError struct {
+	// File 为配置文件路径或环境变量名
+	File string
+	// Line 和 Column 为 0 时表示没有位置信息
+	Line    int
+	Column  int
+	Pointer string // JSON Pointer，例如 /github/openActionPage
+	Message string
+	// Suggestion 可能的修正，例如 "GitHub"
+	Suggestion string
+}
+
+func (e ValidationError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", e.File, e.Line, e.Column)
+	}
+
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	msg := fmt.Sprintf("%s: %s: %s", location, pointer, e.Message)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s?)", e.Suggestion)
+	}
+	return msg
+}
+
+// ValidationErrors 多个配置校验错误
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate 按照 schema 校验节点，file 用于错误信息
+func (s *Schema) Validate(node *Node, file string) ValidationErrors {
+	v := &validator{file: file}
+	v.validate(s, node, "", node.Line, node.Column)
+	return v.errors
+}
+
+// Lookup 返回点分隔 key 对应的子 schema
+func (s *Schema) Lookup(key string) (*Schema, bool) {
+	current := s
+	for _, part := range strings.Split(key, ".") {
+		next, ok := current.Properties[part]
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+// validator 收集校验过程中的错误
+type validator struct {
+	file   string
+	errors ValidationErrors
+}
+
+func (v *validator) add(line, column int, pointer, message, suggestion string) {
+	v.errors = append(v.errors, ValidationError{
+		File:       v.file,
+		Line:       line,
+		Column:     column,
+		Pointer:    pointer,
+		Message:    message,
+		Suggestion: suggestion,
+	})
+}
+
+func (v *validator) validate(s *Schema, node *Node, pointer string, line, column int) {
+	if s.Type != "" && !typeMatches(s.Type, node) {
+		v.add(line, column, pointer, fmt.Sprintf("expected %s, got %s", s.Type, node.Kind), "")
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		v.validateEnum(s, node, pointer, line, column)
+	}
+
+	switch node.Kind {
+	case ObjectNode:
+		v.validateObject(s, node, pointer)
+	case ArrayNode:
+		if s.Items != nil {
+			for i, item := range node.Items {
+				v.validate(s.Items, item, fmt.Sprintf("%s/%d", pointer, i), item.Line, item.Column)
+			}
+		}
+	}
+}
+
+func (v *validator) validateEnum(s *Schema, node *Node, pointer string, line, column int) {
+	value := node.Interface()
+	options := make([]string, 0, len(s.Enum))
+	for _, option := range s.Enum {
+		if option == value {
+			return
+		}
+		options = append(options, fmt.Sprint(option))
+	}
+
+	suggestion := ""
+	if str, ok := value.(string); ok {
+		if match := closest(str, options); match != "" {
+			suggestion = fmt.Sprintf("%q", match)
+		}
+	}
+
+	v.add(line, column, pointer,
+		fmt.Sprintf("value %s is not one of %s", formatJSON(value), strings.Join(quoteAll(options), ", ")),
+		suggestion)
+}
+
+func (v *validator) validateObject(s *Schema, node *Node, pointer string) {
+	known := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+
+	for _, member := range node.Members {
+		childPointer := pointer + "/" + escapePointer(member.Key)
+
+		child, ok := s.Properties[member.Key]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				suggestion := ""
+				if match := closest(member.Key, known); match != "" {
+					suggestion = fmt.Sprintf("%q", match)
+				}
+				v.add(member.Line, member.Column, childPointer, fmt.Sprintf("unknown property %q", member.Key), suggestion)
+			}
+			continue
+		}
+
+		v.validate(child, member.Value, childPointer, member.Value.Line, member.Value.Column)
+	}
+
+	for _, name := range s.Required {
+		if _, ok := node.Get(name); !ok {
+			v.add(node.Line, node.Column, pointer, fmt.Sprintf("missing required property %q", name), "")
+		}
+	}
+}
+
+func typeMatches(typ string, node *Node) bool {
+	switch typ {
+	case "integer":
+		if node.Kind != NumberNode {
+			return false
+		}
+		_, err := node.Value.(json.Number).Int64()
+		return err == nil
+	case "number":
+		return node.Kind == NumberNode
+	default:
+		return node.Kind.String() == typ
+	}
+}
+
+// closest 返回与 s 最接近的候选项：忽略大小写相等，或编辑距离不超过 2
+func closest(s string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, c := range candidates {
+		if strings.EqualFold(s, c) {
+			return c
+		}
+		if d := levenshtein(strings.ToLower(s), strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+// levenshtein 计算两个字符串的编辑距离
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// escapePointer 按 RFC 6901 转义 JSON Pointer 中的 key
+func escapePointer(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return strings.ReplaceAll(key, "/", "~1")
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return quoted
+}
+
+func formatJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestSchemaUpToDate 确保仓库中的 tagger.schema.json 与配置类型保持同步
+func TestSchemaUpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../../tagger.schema.json")
+	if err != nil {
+		t.Fatalf("failed to read tagger.schema.json: %v", err)
+	}
+
+	generated, err := GenerateSchemaJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(committed, generated) {
+		t.Fatal("tagger.schema.json is out of date, run `go generate ./internal/config` to regenerate it")
+	}
+}
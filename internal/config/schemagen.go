@@ -0,0 +1,121 @@
+package config
+
+//go:generate sh -c "go run ../.. config schema > ../../tagger.schema.json"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	schemaDraft       = "https://json-schema.org/draft/2020-12/schema"
+	schemaTitle       = "Tagger Configuration"
+	schemaDescription = "Configuration file for Tagger - Git semantic version tag management tool"
+)
+
+// enumType 实现该接口的类型在 schema 中生成 enum
+type enumType interface {
+	EnumValues() []string
+}
+
+var enumInterface = reflect.TypeOf((*enumType)(nil)).Elem()
+
+// GenerateSchema 根据 Config 结构体及其 description、default tag 生成 JSON Schema
+func GenerateSchema() *Schema {
+	s := structSchema(reflect.TypeOf(Config{}))
+	s.SchemaURI = schemaDraft
+	s.ID = SchemaURL
+	s.Title = schemaTitle
+	s.Description = schemaDescription
+	return s
+}
+
+// GenerateSchemaJSON 返回格式化后的 schema，与仓库中的 tagger.schema.json 保持一致
+func GenerateSchemaJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(GenerateSchema()); err != nil {
+		return nil, fmt.Errorf("failed to generate schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func structSchema(t reflect.Type) *Schema {
+	additional := false
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: &additional,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		prop := typeSchema(f.Type)
+		prop.Description = f.Tag.Get("description")
+		if def, ok := f.Tag.Lookup("default"); ok {
+			prop.Default = parseDefault(prop.Type, def)
+		}
+
+		s.Properties[name] = prop
+		if f.Tag.Get("required") == "true" {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
+
+func typeSchema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Implements(enumInterface) {
+		values := reflect.Zero(t).Interface().(enumType).EnumValues()
+		enum := make([]any, 0, len(values))
+		for _, v := range values {
+			enum = append(enum, v)
+		}
+		return &Schema{Type: "string", Enum: enum}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem())}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// parseDefault 将 default tag 转换为对应类型的值
+func parseDefault(typ, raw string) any {
+	switch typ {
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case "integer":
+		if n, err := strconv.Atoi(raw); err == nil {
+			return n
+		}
+	}
+	return raw
+}
@@ -0,0 +1,126 @@
+package detect
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/config"
+)
+
+// versionPattern 匹配 tag 末尾的语义化版本号，捕获其前缀
+var versionPattern = regexp.MustCompile(`^(.*?)(\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.+-]*)?)$`)
+
+// skipDirs 扫描包时跳过的目录
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+}
+
+// Provider 根据远程仓库 URL 判断托管平台，无法识别时返回 Other
+func Provider(remoteURL string) config.GitHostingProvider {
+	parsedURL, err := url.Parse(remoteURL)
+	if err != nil {
+		return config.Other
+	}
+
+	if parsedURL.Hostname() == "github.com" {
+		return config.GitHub
+	}
+	return config.Other
+}
+
+// TagPrefix 根据已有的 tags 推断仓库根目录版本的 tag 前缀
+// 只考虑不包含 / 的 tag（monorepo 中包的 tag 通常为 <path>/vX.Y.Z），没有版本 tag 时返回 v
+func TagPrefix(tags []string) string {
+	counts := make(map[string]int)
+	for _, tag := range tags {
+		match := versionPattern.FindStringSubmatch(tag)
+		if match == nil || strings.Contains(match[1], "/") {
+			continue
+		}
+		counts[match[1]]++
+	}
+
+	prefix, best := config.DefaultTagPrefix, 0
+	for p, n := range counts {
+		// 数量相同时优先使用默认前缀，保证结果稳定
+		if n > best || (n == best && p == config.DefaultTagPrefix) {
+			prefix, best = p, n
+		}
+	}
+	return prefix
+}
+
+// Packages 扫描 root 的子目录，将包含 go.mod 或 package.json 的目录作为 monorepo 中的包
+// Go 模块使用 <path>/v 作为 tag 前缀，npm 包使用 <name>@
+func Packages(root string) ([]config.PackageConfig, error) {
+	var packages []config.PackageConfig
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skipDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		if dir == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch d.Name() {
+		case "go.mod":
+			packages = append(packages, config.PackageConfig{Name: rel, Path: rel, TagPrefix: rel + "/v"})
+		case "package.json":
+			name := packageName(path)
+			if name == "" {
+				name = filepath.Base(dir)
+			}
+			packages = append(packages, config.PackageConfig{Name: name, Path: rel, TagPrefix: name + "@"})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Path < packages[j].Path
+	})
+	return packages, nil
+}
+
+// packageName 读取 package.json 中的 name 字段
+func packageName(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Name
+}
@@ -0,0 +1,127 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultEditor 未配置任何编辑器时使用的编辑器，与 git 保持一致
+const DefaultEditor = "vi"
+
+// autoCommentChars 自动选择注释字符时的候选，顺序与 git 的 core.commentChar=auto 一致
+const autoCommentChars = "#;@!$%^&|:"
+
+// Resolve 按照 git 的优先级选择编辑器：$GIT_EDITOR、core.editor、$VISUAL、$EDITOR
+func Resolve(coreEditor string) string {
+	if e := os.Getenv("GIT_EDITOR"); e != "" {
+		return e
+	}
+	if coreEditor != "" {
+		return coreEditor
+	}
+	if e := os.Getenv("VISUAL"); e != "" {
+		return e
+	}
+	if e := os.Getenv("EDITOR"); e != "" {
+		return e
+	}
+	return DefaultEditor
+}
+
+// Command 构造打开文件的编辑器命令
+// 与 git 一样通过 shell 执行，以支持 "code --wait" 这类带参数的编辑器配置
+func Command(editor, path string) *exec.Cmd {
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+}
+
+// CommentChar 根据 core.commentChar 选择注释字符
+// 未配置或配置为 auto 时，与 git 一样选择一个不会与内容行首冲突的字符
+func CommentChar(configured, content string) byte {
+	if configured != "" && configured != "auto" {
+		return configured[0]
+	}
+
+	for i := 0; i < len(autoCommentChars); i++ {
+		c := autoCommentChars[i]
+		if !startsAnyLine(content, c) {
+			return c
+		}
+	}
+	return '#'
+}
+
+// WriteTemp 将内容和说明注释写入临时文件，返回文件路径
+func WriteTemp(content string, commentChar byte, help []string) (string, error) {
+	f, err := os.CreateTemp("", "TAG_EDITMSG-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer f.Close()
+
+	var sb strings.Builder
+	sb.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	for _, line := range help {
+		sb.WriteString(fmt.Sprintf("%c %s\n", commentChar, line))
+	}
+
+	if _, err := f.WriteString(sb.String()); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+// ReadResult 读取编辑后的文件并删除，返回去掉注释后的内容
+func ReadResult(path string, commentChar byte) (string, error) {
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited message: %w", err)
+	}
+
+	return StripComments(string(data), commentChar), nil
+}
+
+// StripComments 按照 git stripspace --strip-comments 的规则清理内容：
+// 删除注释行和行尾空白，合并连续空行，并去掉首尾空行
+func StripComments(text string, commentChar byte) string {
+	var lines []string
+	blank := false
+
+	for _, line := range strings.Split(text, "\n") {
+		if len(line) > 0 && line[0] == commentChar {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func startsAnyLine(content string, c byte) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if len(line) > 0 && line[0] == c {
+			return true
+		}
+	}
+	return false
+}
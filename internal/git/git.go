@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,7 +17,12 @@ type GitClient struct {
 // TagInfo 包含 tag 的信息
 type TagInfo struct {
 	Name string
+	// Date annotated tag 的创建时间，lightweight tag 为 commit 的提交时间
 	Date time.Time
+	// Commit tag 指向的 commit hash
+	Commit string
+	// Annotated 为 true 时是 annotated tag，否则是 lightweight tag
+	Annotated bool
 }
 
 // NewGitClient 创建一个新的 GitClient
@@ -34,9 +40,23 @@ func (g *GitClient) IsGitRepository() (bool, error) {
 	return true, nil
 }
 
-// HasUncommittedChanges 检查是否有未提交的修改
+// HasUncommittedChanges 检查是否有未提交的修改，包括未跟踪的文件
 func (g *GitClient) HasUncommittedChanges() (bool, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
+	return g.hasChanges(true)
+}
+
+// HasTrackedChanges 检查已跟踪的文件是否有未提交的修改，与 git describe --dirty 的规则一致
+func (g *GitClient) HasTrackedChanges() (bool, error) {
+	return g.hasChanges(false)
+}
+
+func (g *GitClient) hasChanges(untracked bool) (bool, error) {
+	args := []string{"status", "--porcelain"}
+	if !untracked {
+		args = append(args, "--untracked-files=no")
+	}
+
+	cmd := exec.Command("git", args...)
 	cmd.Dir = g.workDir
 
 	var out bytes.Buffer
@@ -70,6 +90,23 @@ func (g *GitClient) GetAllTags() ([]string, error) {
 	return tags, nil
 }
 
+// GetMergedTags 获取可以从 ref 到达的 tags，与 git describe 选择 tag 的范围一致
+func (g *GitClient) GetMergedTags(ref string) (map[string]bool, error) {
+	output, err := g.output("tag", "--merged", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags merged into %s: %w", ref, err)
+	}
+
+	tags := make(map[string]bool)
+	if output == "" {
+		return tags, nil
+	}
+	for _, name := range strings.Split(output, "\n") {
+		tags[name] = true
+	}
+	return tags, nil
+}
+
 // TagExists 检查指定的 tag 是否存在
 func (g *GitClient) TagExists(tag string) (bool, error) {
 	cmd := exec.Command("git", "tag", "-l", tag)
@@ -115,6 +152,21 @@ func (g *GitClient) CreateAnnotatedTag(version, message string) error {
 	return nil
 }
 
+// CreateSignedTag 创建 GPG 签名的 tag，签名 tag 必须带有 message
+func (g *GitClient) CreateSignedTag(version, message string) error {
+	cmd := exec.Command("git", "tag", "-s", version, "-m", message)
+	cmd.Dir = g.workDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create signed tag: %s", stderr.String())
+	}
+
+	return nil
+}
+
 // HasRemote 检查是否配置了远程仓库
 func (g *GitClient) HasRemote() (bool, error) {
 	cmd := exec.Command("git", "remote")
@@ -178,6 +230,26 @@ func (g *GitClient) PushTag(version string) error {
 	return nil
 }
 
+// PushBranch 将当前分支推送到远程仓库的同名分支
+func (g *GitClient) PushBranch() error {
+	remote, err := g.GetRemoteName()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("git", "push", remote, "HEAD")
+	cmd.Dir = g.workDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to push branch: %s", stderr.String())
+	}
+
+	return nil
+}
+
 // GetRemoteURL 获取远程仓库的 URL
 func (g *GitClient) GetRemoteURL() (string, error) {
 	remote, err := g.GetRemoteName()
@@ -211,9 +283,10 @@ func (g *GitClient) GetRemoteURL() (string, error) {
 	return url, nil
 }
 
-// GetTagsWithDates 获取所有 tags 及其创建日期
+// GetTagsWithDates 获取所有 tags 及其创建日期、指向的 commit 和类型，按创建时间从新到旧排序
 func (g *GitClient) GetTagsWithDates() ([]TagInfo, error) {
-	cmd := exec.Command("git", "for-each-ref", "--sort=-creatordate", "--format=%(refname:short)|%(creatordate:short)", "refs/tags")
+	cmd := exec.Command("git", "for-each-ref", "--sort=-creatordate",
+		"--format=%(refname:short)|%(creatordate:iso-strict)|%(objecttype)|%(objectname)|%(*objectname)", "refs/tags")
 	cmd.Dir = g.workDir
 
 	var out bytes.Buffer
@@ -233,21 +306,549 @@ func (g *GitClient) GetTagsWithDates() ([]TagInfo, error) {
 
 	for _, line := range lines {
 		parts := strings.Split(line, "|")
-		if len(parts) != 2 {
+		if len(parts) != 5 {
 			continue
 		}
 
-		date, err := time.Parse("2006-01-02", parts[1])
+		date, err := time.Parse(time.RFC3339, parts[1])
 		if err != nil {
 			// 如果解析失败，使用零值时间
 			date = time.Time{}
 		}
 
+		// annotated tag 指向 tag 对象，*objectname 是其指向的 commit
+		annotated := parts[2] == "tag"
+		commit := parts[3]
+		if annotated {
+			commit = parts[4]
+		}
+
 		tagInfos = append(tagInfos, TagInfo{
-			Name: parts[0],
-			Date: date,
+			Name:      parts[0],
+			Date:      date,
+			Commit:    commit,
+			Annotated: annotated,
 		})
 	}
 
 	return tagInfos, nil
 }
+
+// CommitInfo 包含 commit 的信息
+type CommitInfo struct {
+	Hash      string
+	ShortHash string
+	Author    string
+	Email     string
+	Date      time.Time
+	Subject   string
+	Body      string
+}
+
+// FileChange 包含单个文件的变更统计
+type FileChange struct {
+	Path      string
+	Additions int
+	Deletions int
+	Binary    bool
+}
+
+// ResolveCommit 将 ref（tag、分支或 HEAD）解析为完整的 commit hash
+func (g *GitClient) ResolveCommit(ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = g.workDir
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("unknown revision: %s", ref)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// GetCommitsBetween 获取 from..to 之间的 commits（不含 merge commit），from 为空时获取 to 的全部历史
+// 指定 paths 时只返回修改了这些路径的 commits
+func (g *GitClient) GetCommitsBetween(from, to string, paths ...string) ([]CommitInfo, error) {
+	revRange := to
+	if from != "" {
+		revRange = from + ".." + to
+	}
+
+	args := []string{"log", "--no-merges", "--format=" + commitFormat, revRange}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.workDir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to get commits: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return parseCommits(out.String()), nil
+}
+
+// GetCommit 获取单个 commit 的信息
+func (g *GitClient) GetCommit(ref string) (CommitInfo, error) {
+	cmd := exec.Command("git", "log", "-1", "--format="+commitFormat, ref)
+	cmd.Dir = g.workDir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to get commit: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	commits := parseCommits(out.String())
+	if len(commits) == 0 {
+		return CommitInfo{}, fmt.Errorf("unknown revision: %s", ref)
+	}
+
+	return commits[0], nil
+}
+
+// commitFormat git log 的输出格式，字段以 \x1f 分隔，记录以 \x1e 分隔
+const commitFormat = "%H%x1f%h%x1f%an%x1f%ae%x1f%aI%x1f%s%x1f%b%x1e"
+
+// parseCommits 解析 commitFormat 格式的 git log 输出
+func parseCommits(output string) []CommitInfo {
+	records := strings.Split(output, "\x1e")
+	commits := make([]CommitInfo, 0, len(records))
+
+	for _, record := range records {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.Split(record, "\x1f")
+		if len(fields) != 7 {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[4])
+		if err != nil {
+			date = time.Time{}
+		}
+
+		commits = append(commits, CommitInfo{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Author:    fields[2],
+			Email:     fields[3],
+			Date:      date,
+			Subject:   fields[5],
+			Body:      strings.TrimSpace(fields[6]),
+		})
+	}
+
+	return commits
+}
+
+// GetChangedFiles 获取 from 和 to 之间变更的文件统计
+func (g *GitClient) GetChangedFiles(from, to string) ([]FileChange, error) {
+	cmd := exec.Command("git", "diff", "--numstat", from, to)
+	cmd.Dir = g.workDir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	output := strings.TrimSpace(out.String())
+	if output == "" {
+		return []FileChange{}, nil
+	}
+
+	lines := strings.Split(output, "\n")
+	changes := make([]FileChange, 0, len(lines))
+
+	for _, line := range lines {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		change := FileChange{Path: parts[2]}
+
+		// 二进制文件的增删行数显示为 "-"
+		if parts[0] == "-" && parts[1] == "-" {
+			change.Binary = true
+		} else {
+			change.Additions, _ = strconv.Atoi(parts[0])
+			change.Deletions, _ = strconv.Atoi(parts[1])
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// GetConfig 读取 git 配置项，未设置时返回空字符串
+func (g *GitClient) GetConfig(key string) (string, error) {
+	cmd := exec.Command("git", "config", "--get", key)
+	cmd.Dir = g.workDir
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		// 退出码 1 表示配置项不存在
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read git config %s: %w", key, err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// GetCurrentBranch 获取当前分支名，detached HEAD 时返回 "HEAD"
+func (g *GitClient) GetCurrentBranch() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = g.workDir
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// GetTopLevel 获取仓库根目录的绝对路径
+func (g *GitClient) GetTopLevel() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = g.workDir
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// Commit 提交指定的文件，paths 相对于仓库根目录，暂存区中的其他修改不会被提交
+func (g *GitClient) Commit(message string, paths ...string) error {
+	pathspecs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		pathspecs = append(pathspecs, ":(top)"+p)
+	}
+
+	add := exec.Command("git", append([]string{"add", "--"}, pathspecs...)...)
+	add.Dir = g.workDir
+
+	var stderr bytes.Buffer
+	add.Stderr = &stderr
+
+	if err := add.Run(); err != nil {
+		return fmt.Errorf("failed to stage files: %s", stderr.String())
+	}
+
+	commit := exec.Command("git", append([]string{"commit", "-m", message, "--"}, pathspecs...)...)
+	commit.Dir = g.workDir
+
+	stderr.Reset()
+	commit.Stderr = &stderr
+
+	if err := commit.Run(); err != nil {
+		return fmt.Errorf("failed to commit: %s", stderr.String())
+	}
+
+	return nil
+}
+
+// AddWorktree 在 dir 创建指向 ref 的 detached worktree，只使用本地对象
+func (g *GitClient) AddWorktree(dir, ref string) error {
+	cmd := exec.Command("git", "worktree", "add", "--detach", "--quiet", dir, ref)
+	cmd.Dir = g.workDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to add worktree for %s: %s", ref, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// RemoveWorktree 删除 AddWorktree 创建的 worktree
+func (g *GitClient) RemoveWorktree(dir string) error {
+	cmd := exec.Command("git", "worktree", "remove", "--force", dir)
+	cmd.Dir = g.workDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to remove worktree: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// GetCommitTime 获取 commit 的提交时间（committer date）
+func (g *GitClient) GetCommitTime(ref string) (time.Time, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%cI", ref)
+	cmd.Dir = g.workDir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit time: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return time.Parse(time.RFC3339, strings.TrimSpace(out.String()))
+}
+
+// CountCommits 统计 from..to 之间的 commit 数量，from 为空时统计 to 的全部历史
+func (g *GitClient) CountCommits(from, to string) (int, error) {
+	revRange := to
+	if from != "" {
+		revRange = from + ".." + to
+	}
+
+	cmd := exec.Command("git", "rev-list", "--count", revRange)
+	cmd.Dir = g.workDir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("failed to count commits: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return strconv.Atoi(strings.TrimSpace(out.String()))
+}
+
+// GetDefaultBranch 获取默认分支：优先使用远程仓库的 HEAD（例如 origin/main），
+// 否则使用本地的 main 或 master，都不存在时使用当前分支
+func (g *GitClient) GetDefaultBranch() (string, error) {
+	if hasRemote, err := g.HasRemote(); err == nil && hasRemote {
+		remote, err := g.GetRemoteName()
+		if err != nil {
+			return "", err
+		}
+
+		cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/"+remote+"/HEAD")
+		cmd.Dir = g.workDir
+
+		var out bytes.Buffer
+		cmd.Stdout = &out
+
+		if err := cmd.Run(); err == nil {
+			return strings.TrimSpace(out.String()), nil
+		}
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if _, err := g.ResolveCommit("refs/heads/" + branch); err == nil {
+			return branch, nil
+		}
+	}
+
+	return g.GetCurrentBranch()
+}
+
+// IsAncestor 判断 commit 是否可以从 ref 到达
+func (g *GitClient) IsAncestor(commit, ref string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", commit, ref)
+	cmd.Dir = g.workDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+
+	// 退出码 1 表示不是祖先，其他退出码表示出错
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check ancestry of %s: %s", commit, strings.TrimSpace(stderr.String()))
+}
+
+// CopyTag 创建指向与 from 相同对象的 tag to
+// annotated tag 会复制 tag 对象，保留 tagger、时间和 message；签名在改名后失效，因此会被去掉，返回 signed 为 true
+func (g *GitClient) CopyTag(from, to string) (signed bool, err error) {
+	objectType, err := g.output("cat-file", "-t", "refs/tags/"+from)
+	if err != nil {
+		return false, fmt.Errorf("failed to read tag %s: %w", from, err)
+	}
+
+	object := "refs/tags/" + from
+	if objectType == "tag" {
+		// tag 对象需要原样读取，message 末尾的换行也是内容的一部分
+		cat := exec.Command("git", "cat-file", "tag", "refs/tags/"+from)
+		cat.Dir = g.workDir
+
+		var content bytes.Buffer
+		cat.Stdout = &content
+
+		if err := cat.Run(); err != nil {
+			return false, fmt.Errorf("failed to read tag %s: %w", from, err)
+		}
+
+		var rewritten string
+		rewritten, signed = renameTagObject(content.String(), to)
+
+		cmd := exec.Command("git", "mktag")
+		cmd.Dir = g.workDir
+		cmd.Stdin = strings.NewReader(rewritten)
+
+		var out, stderr bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return false, fmt.Errorf("failed to create tag object for %s: %s", to, strings.TrimSpace(stderr.String()))
+		}
+		object = strings.TrimSpace(out.String())
+	} else {
+		object, err = g.output("rev-parse", "refs/tags/"+from)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve tag %s: %w", from, err)
+		}
+	}
+
+	// 空的旧值保证不会覆盖已存在的 tag
+	if _, err := g.output("update-ref", "refs/tags/"+to, object, ""); err != nil {
+		return false, fmt.Errorf("failed to create tag %s: %w", to, err)
+	}
+	return signed, nil
+}
+
+// renameTagObject 修改 tag 对象中的 tag 名并去掉签名，返回是否去掉了签名
+func renameTagObject(content, name string) (string, bool) {
+	header, message, _ := strings.Cut(content, "\n\n")
+
+	signed := false
+	var lines []string
+	inSignature := false
+	for _, line := range strings.Split(header, "\n") {
+		// gpgsig 头及其续行（以空格开头）
+		if strings.HasPrefix(line, "gpgsig") {
+			signed, inSignature = true, true
+			continue
+		}
+		if inSignature && strings.HasPrefix(line, " ") {
+			continue
+		}
+		inSignature = false
+
+		if strings.HasPrefix(line, "tag ") {
+			line = "tag " + name
+		}
+		lines = append(lines, line)
+	}
+
+	// message 末尾的 PGP 或 SSH 签名
+	for _, marker := range []string{"-----BEGIN PGP SIGNATURE-----", "-----BEGIN SSH SIGNATURE-----"} {
+		if i := strings.Index(message, marker); i >= 0 {
+			message, signed = message[:i], true
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n\n" + message, signed
+}
+
+// DeleteTag 删除本地的 tag，可以一次删除多个
+func (g *GitClient) DeleteTag(names ...string) error {
+	args := append([]string{"tag", "-d"}, names...)
+	if _, err := g.output(args...); err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", strings.Join(names, ", "), err)
+	}
+	return nil
+}
+
+// DeleteRemoteTag 删除远程仓库中的 tag，多个 tag 通过一次 push 删除
+func (g *GitClient) DeleteRemoteTag(names ...string) error {
+	remote, err := g.GetRemoteName()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"push", remote, "--delete"}
+	for _, name := range names {
+		args = append(args, "refs/tags/"+name)
+	}
+	if _, err := g.output(args...); err != nil {
+		return fmt.Errorf("failed to delete remote tag %s: %w", strings.Join(names, ", "), err)
+	}
+	return nil
+}
+
+// GetRemoteTags 获取远程仓库中的 tags，返回 tag 名到其指向的 commit 的映射
+func (g *GitClient) GetRemoteTags() (map[string]string, error) {
+	remote, err := g.GetRemoteName()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := g.output("ls-remote", "--tags", remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote tags: %w", err)
+	}
+
+	tags := make(map[string]string)
+	if output == "" {
+		return tags, nil
+	}
+	for _, line := range strings.Split(output, "\n") {
+		hash, ref, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		name := strings.TrimPrefix(ref, "refs/tags/")
+
+		// annotated tag 额外有一行 name^{} 指向其 commit
+		if peeled, ok := strings.CutSuffix(name, "^{}"); ok {
+			tags[peeled] = hash
+			continue
+		}
+		if _, ok := tags[name]; !ok {
+			tags[name] = hash
+		}
+	}
+	return tags, nil
+}
+
+// output 执行 git 命令并返回去掉首尾空白的标准输出，失败时错误中包含标准错误
+func (g *GitClient) output(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.workDir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s", strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
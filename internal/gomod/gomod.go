@@ -0,0 +1,240 @@
+package gomod
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/versionfile"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// FileName Go 模块的描述文件
+const FileName = "go.mod"
+
+// Module 仓库中的一个 Go 模块
+type Module struct {
+	// Dir 模块目录，相对于仓库根目录，仓库根目录为空字符串
+	Dir string
+	// Path 模块路径，例如 github.com/x/y/v2
+	Path string
+	File *modfile.File
+	data []byte
+}
+
+// GoModPath 返回 go.mod 相对于仓库根目录的路径
+func (m *Module) GoModPath() string {
+	return path.Join(m.Dir, FileName)
+}
+
+// Load 读取 dir 下的 go.mod，dir 相对于 root，文件不存在时返回 nil
+func Load(root, dir string) (*Module, error) {
+	dir = strings.Trim(path.Clean(filepath.ToSlash(dir)), "/")
+	if dir == "." {
+		dir = ""
+	}
+
+	file := filepath.Join(root, filepath.FromSlash(dir), FileName)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	f, err := modfile.Parse(file, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if f.Module == nil {
+		return nil, fmt.Errorf("%s: missing module directive", path.Join(dir, FileName))
+	}
+
+	return &Module{Dir: dir, Path: f.Module.Mod.Path, File: f, data: data}, nil
+}
+
+// Retracted 判断 version（例如 v1.4.2）是否被 go.mod 中的 retract 指令撤回，返回撤回的原因
+func (m *Module) Retracted(version string) (rationale string, ok bool) {
+	for _, r := range m.File.Retract {
+		if semver.Compare(version, r.Low) >= 0 && semver.Compare(version, r.High) <= 0 {
+			return r.Rationale, true
+		}
+	}
+	return "", false
+}
+
+// Retract 计算在 go.mod 中加入撤回 [low, high] 的 retract 指令后的修改，不写入文件
+// low 和 high 相同时撤回单个版本
+func Retract(m *Module, low, high, rationale string) (versionfile.Change, error) {
+	if semver.Compare(low, high) > 0 {
+		return versionfile.Change{}, fmt.Errorf("invalid retract range: %s is greater than %s", low, high)
+	}
+	// 与已有的 retract 指令重叠时不再添加，包括新的范围完整包含已有范围的情况
+	for _, r := range m.File.Retract {
+		if semver.Compare(low, r.High) > 0 || semver.Compare(r.Low, high) > 0 {
+			continue
+		}
+		reason := ""
+		if r.Rationale != "" {
+			reason = ": " + r.Rationale
+		}
+		return versionfile.Change{}, fmt.Errorf("%s overlaps %s, which is already retracted in %s%s",
+			versionRange(low, high), versionRange(r.Low, r.High), m.GoModPath(), reason)
+	}
+
+	f, err := modfile.Parse(m.GoModPath(), m.data, nil)
+	if err != nil {
+		return versionfile.Change{}, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if err := f.AddRetract(modfile.VersionInterval{Low: low, High: high}, rationale); err != nil {
+		return versionfile.Change{}, fmt.Errorf("failed to add retract directive: %w", err)
+	}
+	data, err := f.Format()
+	if err != nil {
+		return versionfile.Change{}, fmt.Errorf("failed to format go.mod: %w", err)
+	}
+
+	return versionfile.Change{Path: m.GoModPath(), Old: string(m.data), New: string(data)}, nil
+}
+
+// versionRange 返回 retract 范围的显示形式，单个版本时只显示该版本
+func versionRange(low, high string) string {
+	if low == high {
+		return low
+	}
+	return fmt.Sprintf("[%s, %s]", low, high)
+}
+
+// CheckMajor 检查模块路径的主版本后缀是否与 major 匹配
+// v2 及以上的版本要求路径以 /vN 结尾（gopkg.in 为 .vN），v0 和 v1 不能带有后缀
+func CheckMajor(modulePath string, major uint64) error {
+	_, pathMajor, ok := module.SplitPathVersion(modulePath)
+	if !ok {
+		return fmt.Errorf("invalid module path %q", modulePath)
+	}
+	if err := module.CheckPathMajor(fmt.Sprintf("v%d.0.0", major), pathMajor); err != nil {
+		return fmt.Errorf("module %s cannot be tagged v%d: the module path must be %s", modulePath, major, MajorPath(modulePath, major))
+	}
+	return nil
+}
+
+// MajorPath 返回将 modulePath 的主版本后缀替换为 major 后的路径
+func MajorPath(modulePath string, major uint64) string {
+	prefix, _, ok := module.SplitPathVersion(modulePath)
+	if !ok {
+		prefix = modulePath
+	}
+
+	switch {
+	case strings.HasPrefix(modulePath, "gopkg.in/"):
+		return fmt.Sprintf("%s.v%d", prefix, major)
+	case major < 2:
+		return prefix
+	default:
+		return fmt.Sprintf("%s/v%d", prefix, major)
+	}
+}
+
+// RewritePath 计算将模块路径改为 newPath 后 go.mod 以及模块内 Go 文件的修改，不写入文件
+// 只修改引用该模块自身包的 import，嵌套的模块和 vendor 目录会被跳过
+func RewritePath(root string, m *Module, newPath string) ([]versionfile.Change, error) {
+	// 重新解析一份 go.mod 进行修改，m 仍然描述磁盘上的文件
+	f, err := modfile.Parse(m.GoModPath(), m.data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if err := f.AddModuleStmt(newPath); err != nil {
+		return nil, fmt.Errorf("failed to update module path: %w", err)
+	}
+	newMod, err := f.Format()
+	if err != nil {
+		return nil, fmt.Errorf("failed to format go.mod: %w", err)
+	}
+
+	changes := []versionfile.Change{{Path: m.GoModPath(), Old: string(m.data), New: string(newMod)}}
+
+	moduleRoot := filepath.Join(root, filepath.FromSlash(m.Dir))
+	err = filepath.WalkDir(moduleRoot, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if file == moduleRoot {
+				return nil
+			}
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(file, FileName)); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(file, ".go") {
+			return nil
+		}
+
+		change, err := rewriteImports(file, m.Path, newPath)
+		if err != nil || !change.Changed() {
+			return err
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		change.Path = filepath.ToSlash(rel)
+		changes = append(changes, change)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite imports: %w", err)
+	}
+	return changes, nil
+}
+
+// rewriteImports 将 file 中 oldPath 及其子包的 import 替换为 newPath
+func rewriteImports(file, oldPath, newPath string) (versionfile.Change, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return versionfile.Change{}, err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, data, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return versionfile.Change{}, err
+	}
+
+	content := string(data)
+	var sb strings.Builder
+	last := 0
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if importPath != oldPath && !strings.HasPrefix(importPath, oldPath+"/") {
+			continue
+		}
+
+		start := fset.Position(spec.Path.Pos()).Offset
+		end := fset.Position(spec.Path.End()).Offset
+		sb.WriteString(content[last:start])
+		sb.WriteString(strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)))
+		last = end
+	}
+	sb.WriteString(content[last:])
+
+	return versionfile.Change{Old: content, New: sb.String()}, nil
+}
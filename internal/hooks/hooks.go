@@ -0,0 +1,107 @@
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+)
+
+// 生命周期钩子的名称，按执行顺序排列
+const (
+	PreBump  = "preBump"
+	PreTag   = "preTag"
+	PostTag  = "postTag"
+	PrePush  = "prePush"
+	PostPush = "postPush"
+)
+
+// Env 传递给钩子的环境变量
+type Env struct {
+	Version         string // 新版本，不含 tag 前缀，例如 1.2.3
+	PreviousVersion string // 上一个版本，没有时为空
+	Tag             string // 新 tag 名，例如 v1.2.3
+	Commit          string // 被打 tag 的 commit
+	DryRun          bool
+}
+
+// Environ 返回追加了 TAGGER_* 变量的环境
+func (e Env) Environ() []string {
+	return append(os.Environ(),
+		"TAGGER_VERSION="+e.Version,
+		"TAGGER_PREVIOUS_VERSION="+e.PreviousVersion,
+		"TAGGER_TAG="+e.Tag,
+		"TAGGER_COMMIT="+e.Commit,
+		"TAGGER_DRY_RUN="+strconv.FormatBool(e.DryRun),
+	)
+}
+
+// Runner 在仓库根目录中执行钩子
+type Runner struct {
+	Dir string
+	// Out 钩子的输出，stdout 和 stderr 按行写入，每行带有 Prefix
+	Out    io.Writer
+	Prefix string
+}
+
+// Run 通过 sh -c 执行钩子命令，command 为空时不做任何事
+func (r Runner) Run(name, command string, env Env) error {
+	if command == "" {
+		return nil
+	}
+
+	out := &lineWriter{w: r.Out, prefix: r.Prefix}
+	defer out.Flush()
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = r.Dir
+	cmd.Env = env.Environ()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", name, err)
+	}
+	return nil
+}
+
+// lineWriter 按行输出，在每行前加上前缀；stdout 和 stderr 共用同一个 lineWriter
+type lineWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(l.w, "%s%s\n", l.prefix, l.buf[:i]); err != nil {
+			return 0, err
+		}
+		l.buf = l.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush 输出最后一行没有换行符的内容
+func (l *lineWriter) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.buf) > 0 {
+		fmt.Fprintf(l.w, "%s%s\n", l.prefix, l.buf)
+		l.buf = nil
+	}
+}
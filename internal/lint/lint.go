@@ -0,0 +1,236 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/semver"
+)
+
+// Kind 问题的类型
+type Kind string
+
+const (
+	// Skipped 相邻的两个正式版本之间跳过了版本，例如 v1.3.0 → v1.5.0
+	Skipped Kind = "skipped"
+	// Duplicate 多个 tag 解析为同一个版本，例如 v1.2.0 和 1.2.0
+	Duplicate Kind = "duplicate"
+	// NonConforming tag 不符合任何版本线的格式
+	NonConforming Kind = "non-conforming"
+	// TagType 同一个版本线中同时存在 lightweight 和 annotated tag
+	TagType Kind = "tag-type"
+	// Unreachable tag 指向的 commit 不在默认分支上
+	Unreachable Kind = "unreachable"
+	// DateOrder 更高的版本的创建时间反而更早
+	DateOrder Kind = "date-order"
+)
+
+// Kinds 所有问题类型，按报告中的显示顺序排列
+var Kinds = []Kind{Skipped, Duplicate, NonConforming, TagType, Unreachable, DateOrder}
+
+// kindTitles 报告中每种问题的标题
+var kindTitles = map[Kind]string{
+	Skipped:       "Skipped versions",
+	Duplicate:     "Duplicate versions",
+	NonConforming: "Non-conforming tags",
+	TagType:       "Mixed lightweight and annotated tags",
+	Unreachable:   "Tags not on the default branch",
+	DateOrder:     "Out-of-order dates",
+}
+
+// Title 返回报告中使用的标题
+func (k Kind) Title() string {
+	return kindTitles[k]
+}
+
+// ParseKind 解析问题类型，用于 --ignore
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown check %q (must be one of skipped, duplicate, non-conforming, tag-type, unreachable, date-order)", s)
+}
+
+// Issue 一个问题
+type Issue struct {
+	Kind Kind `json:"kind"`
+	// Line 版本线的名称，仓库根目录为空字符串，packages 中的包为包名
+	Line    string `json:"line,omitempty"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+// Line 一条独立打 tag 的版本线
+type Line struct {
+	Name    string
+	Manager *semver.VersionManager
+}
+
+// Options 检查的参数
+type Options struct {
+	// Reachable 判断 commit 是否可以从默认分支到达，为 nil 时不检查
+	Reachable func(commit string) (bool, error)
+	// DefaultBranch 默认分支名，用于输出
+	DefaultBranch string
+}
+
+// Report 检查结果
+type Report struct {
+	Tags     int     `json:"tags"`
+	Versions int     `json:"versions"`
+	Issues   []Issue `json:"issues"`
+}
+
+// Check 检查 tags 中每条版本线的问题，不属于任何版本线的 tag 报告为 NonConforming
+func Check(tags []git.TagInfo, lines []Line, opts Options) (Report, error) {
+	report := Report{Tags: len(tags), Issues: []Issue{}}
+	matched := make(map[string]bool)
+
+	for _, line := range lines {
+		versionTags, err := line.Manager.ParseTags(tags)
+		if err != nil {
+			return report, err
+		}
+		report.Versions += len(versionTags)
+
+		for _, tag := range versionTags {
+			matched[tag.Name] = true
+			for _, name := range tag.Duplicates {
+				matched[name] = true
+				report.add(Duplicate, line.Name, name, fmt.Sprintf("parses to the same version as %s", tag.Name))
+			}
+		}
+
+		sorted := append([]semver.VersionTag(nil), versionTags...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return line.Manager.Scheme.Compare(sorted[i].Version, sorted[j].Version) < 0
+		})
+
+		report.checkSkipped(line, sorted)
+		report.checkTagType(line, sorted)
+		report.checkDateOrder(line, sorted)
+		if opts.Reachable != nil {
+			if err := report.checkReachable(line, sorted, opts); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	for _, tag := range tags {
+		if !matched[tag.Name] {
+			report.add(NonConforming, "", tag.Name, "does not match the version format of any configured tag prefix")
+		}
+	}
+
+	// 按问题类型分组，同一类型中保持检查的顺序
+	order := make(map[Kind]int, len(Kinds))
+	for i, kind := range Kinds {
+		order[kind] = i
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return order[report.Issues[i].Kind] < order[report.Issues[j].Kind]
+	})
+
+	return report, nil
+}
+
+// Filter 返回去掉 ignored 类型之后的报告
+func (r Report) Filter(ignored []Kind) Report {
+	skip := make(map[Kind]bool, len(ignored))
+	for _, kind := range ignored {
+		skip[kind] = true
+	}
+
+	issues := []Issue{}
+	for _, issue := range r.Issues {
+		if !skip[issue.Kind] {
+			issues = append(issues, issue)
+		}
+	}
+	r.Issues = issues
+	return r
+}
+
+func (r *Report) add(kind Kind, line, tag, message string) {
+	r.Issues = append(r.Issues, Issue{Kind: kind, Line: line, Tag: tag, Message: message})
+}
+
+// checkSkipped 检查相邻的正式版本之间是否跳过了版本，预发布版本不参与检查
+func (r *Report) checkSkipped(line Line, sorted []semver.VersionTag) {
+	var previous *semver.VersionTag
+	for i, tag := range sorted {
+		if tag.Version.Prerelease() != "" {
+			continue
+		}
+		if previous != nil {
+			warning, err := line.Manager.CheckCustomVersion(previous.Version, tag.Version, true)
+			if err == nil && warning != "" {
+				// warning 以版本号开头，例如 "v1.5.0 skips versions: ..."
+				warning = strings.TrimPrefix(warning, line.Manager.FormatVersion(tag.Version)+" ")
+				r.add(Skipped, line.Name, tag.Name, fmt.Sprintf("follows %s and %s", previous.Name, warning))
+			}
+		}
+		previous = &sorted[i]
+	}
+}
+
+// checkTagType 同一个版本线中同时存在两种 tag 时，报告数量较少的一种；数量相同时报告 lightweight tag
+func (r *Report) checkTagType(line Line, sorted []semver.VersionTag) {
+	annotated := 0
+	for _, tag := range sorted {
+		if tag.Info.Annotated {
+			annotated++
+		}
+	}
+	lightweight := len(sorted) - annotated
+	if annotated == 0 || lightweight == 0 {
+		return
+	}
+
+	reportAnnotated := annotated < lightweight
+	for _, tag := range sorted {
+		if tag.Info.Annotated != reportAnnotated {
+			continue
+		}
+		if reportAnnotated {
+			r.add(TagType, line.Name, tag.Name, fmt.Sprintf("is annotated while %d of %d version tags are lightweight", lightweight, len(sorted)))
+		} else {
+			r.add(TagType, line.Name, tag.Name, fmt.Sprintf("is lightweight while %d of %d version tags are annotated", annotated, len(sorted)))
+		}
+	}
+}
+
+// checkDateOrder 检查按版本排序后创建时间是否递增
+func (r *Report) checkDateOrder(line Line, sorted []semver.VersionTag) {
+	for i := 1; i < len(sorted); i++ {
+		previous, tag := sorted[i-1], sorted[i]
+		if previous.Info.Date.IsZero() || tag.Info.Date.IsZero() {
+			continue
+		}
+		if tag.Info.Date.Before(previous.Info.Date) {
+			r.add(DateOrder, line.Name, tag.Name, fmt.Sprintf("was created on %s, before the lower version %s (%s)",
+				tag.Info.Date.Format("2006-01-02 15:04"), previous.Name, previous.Info.Date.Format("2006-01-02 15:04")))
+		}
+	}
+}
+
+// checkReachable 检查 tag 指向的 commit 是否在默认分支上
+func (r *Report) checkReachable(line Line, sorted []semver.VersionTag, opts Options) error {
+	for _, tag := range sorted {
+		if tag.Info.Commit == "" {
+			continue
+		}
+		ok, err := opts.Reachable(tag.Info.Commit)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			r.add(Unreachable, line.Name, tag.Name, fmt.Sprintf("points to a commit that is not reachable from %s", opts.DefaultBranch))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/AkaraChen/tagger/internal/changelog"
+	"github.com/AkaraChen/tagger/internal/git"
+)
+
+// Data 渲染 tag message 模板时可用的数据
+type Data struct {
+	Version         string           // 新版本，例如 v1.2.4
+	PreviousVersion string           // 上一个版本，例如 v1.2.3
+	Date            string           // 当前日期，格式为 2006-01-02
+	Branch          string           // 当前分支
+	Commit          string           // 被打 tag 的 commit hash
+	ShortCommit     string           // 被打 tag 的短 commit hash
+	Author          string           // 创建 tag 的用户
+	Commits         []git.CommitInfo // 自上个版本以来的 commits
+	Groups          []changelog.Group
+	Changelog       string // 按类型分组的 Markdown 变更日志
+	CompareURL      string
+}
+
+// Template 解析后的 tag message 模板
+type Template struct {
+	tmpl *template.Template
+}
+
+// Parse 解析 text/template 格式的 tag message 模板
+func Parse(text string) (*Template, error) {
+	tmpl, err := template.New("messageTemplate").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid messageTemplate: %w", err)
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Render 渲染模板，结果只包含空白时返回空字符串（创建 lightweight tag）
+func (t *Template) Render(data Data) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render messageTemplate: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,167 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/semver"
+)
+
+// Placeholder tag 名格式中版本号的占位符
+const Placeholder = "{version}"
+
+// Format tag 名的格式，例如 v{version}、release-{version} 或 api/v{version}
+type Format struct {
+	Prefix string
+	Suffix string
+}
+
+// ParseFormat 解析 tag 名格式，格式中必须包含一个 {version}
+func ParseFormat(s string) (Format, error) {
+	if strings.Count(s, Placeholder) != 1 {
+		return Format{}, fmt.Errorf("invalid tag format %q: it must contain %s exactly once", s, Placeholder)
+	}
+	prefix, suffix, _ := strings.Cut(s, Placeholder)
+	return Format{Prefix: prefix, Suffix: suffix}, nil
+}
+
+func (f Format) String() string {
+	return f.Prefix + Placeholder + f.Suffix
+}
+
+// Name 返回版本号对应的 tag 名
+func (f Format) Name(version string) string {
+	return f.Prefix + version + f.Suffix
+}
+
+// Match 判断 tag 名是否符合格式，返回其中的版本号
+// 版本号必须是规范的写法，例如格式 {version} 不匹配 v1.2.3 或 1.2，
+// 否则 v 前缀和省略的部分会被当作版本号的一部分带入新的 tag 名
+func (f Format) Match(name string, scheme semver.Scheme) (string, bool) {
+	if len(name) <= len(f.Prefix)+len(f.Suffix) || !strings.HasPrefix(name, f.Prefix) || !strings.HasSuffix(name, f.Suffix) {
+		return "", false
+	}
+	version := name[len(f.Prefix) : len(name)-len(f.Suffix)]
+	v, err := scheme.Parse(version)
+	if err != nil || scheme.Format(v) != version {
+		return "", false
+	}
+	return version, true
+}
+
+// Status 迁移中一个步骤的状态
+type Status string
+
+const (
+	// Pending 尚未执行
+	Pending Status = "pending"
+	// Done 已经完成，包括之前中断的迁移中已经完成的步骤
+	Done Status = "done"
+	// Failed 执行失败
+	Failed Status = "failed"
+)
+
+// Item 一个 tag 的迁移计划，不需要的步骤状态为空
+type Item struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Commit    string `json:"commit"`
+	Annotated bool   `json:"annotated"`
+	// Create 在本地创建新的 tag
+	Create Status `json:"create"`
+	// Push 推送新的 tag
+	Push Status `json:"push,omitempty"`
+	// DeleteRemote、DeleteLocal 删除远程仓库和本地的旧 tag
+	DeleteRemote Status `json:"deleteRemote,omitempty"`
+	DeleteLocal  Status `json:"deleteLocal,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Pending 判断是否还有未完成的步骤
+func (i Item) Pending() bool {
+	for _, status := range []Status{i.Create, i.Push, i.DeleteRemote, i.DeleteLocal} {
+		if status == Pending || status == Failed {
+			return true
+		}
+	}
+	return false
+}
+
+// Options 迁移的参数
+type Options struct {
+	// Push 推送新的 tag，DeleteOld 同时为 true 时也删除远程仓库中的旧 tag
+	Push bool
+	// DeleteOld 删除旧的 tag
+	DeleteOld bool
+	// RemoteTags 远程仓库中的 tag 到 commit 的映射，Push 为 true 时使用
+	RemoteTags map[string]string
+}
+
+// Plan 根据本地和远程仓库的当前状态生成迁移计划，已经完成的步骤标记为 Done，
+// 因此中断的迁移可以用相同的参数重新执行
+// 新的 tag 名已经存在但指向其他 commit 时返回错误
+func Plan(tags []git.TagInfo, from, to Format, scheme semver.Scheme, opts Options) ([]Item, error) {
+	local := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		local[tag.Name] = tag.Commit
+	}
+
+	items := []Item{}
+	var conflicts []string
+	for _, tag := range tags {
+		version, ok := from.Match(tag.Name, scheme)
+		if !ok {
+			continue
+		}
+		// 已经符合新格式的 tag 不需要迁移
+		if _, ok := to.Match(tag.Name, scheme); ok {
+			continue
+		}
+		newName := to.Name(version)
+
+		item := Item{From: tag.Name, To: newName, Commit: tag.Commit, Annotated: tag.Annotated, Create: Pending}
+		if commit, exists := local[newName]; exists {
+			if commit != tag.Commit {
+				conflicts = append(conflicts, fmt.Sprintf("%s already exists and points to %s", newName, short(commit)))
+				continue
+			}
+			item.Create = Done
+		}
+
+		if opts.Push {
+			item.Push = Pending
+			if commit, exists := opts.RemoteTags[newName]; exists {
+				if commit != tag.Commit {
+					conflicts = append(conflicts, fmt.Sprintf("%s already exists on the remote and points to %s", newName, short(commit)))
+					continue
+				}
+				item.Push = Done
+			}
+		}
+
+		if opts.DeleteOld {
+			if opts.Push {
+				item.DeleteRemote = Done
+				if _, exists := opts.RemoteTags[tag.Name]; exists {
+					item.DeleteRemote = Pending
+				}
+			}
+			item.DeleteLocal = Pending
+		}
+
+		items = append(items, item)
+	}
+
+	if len(conflicts) > 0 {
+		return items, fmt.Errorf("conflicting tags:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	return items, nil
+}
+
+func short(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/AkaraChen/tagger/internal/git"
+	"github.com/AkaraChen/tagger/internal/semver"
+)
+
+// TestPlanUnprefixedToPrefixed 确保 {version} 只匹配规范的版本号，不会把 v1.2.3 迁移为 vv1.2.3
+func TestPlanUnprefixedToPrefixed(t *testing.T) {
+	from, err := ParseFormat("{version}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ParseFormat("v{version}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := []git.TagInfo{
+		{Name: "v1.2.3", Commit: "aaaaaaa"},
+		{Name: "1.3.0", Commit: "bbbbbbb"},
+		{Name: "1.4", Commit: "ccccccc"},
+	}
+	items, err := Plan(tags, from, to, semver.SemVer{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d: %+v", len(items), items)
+	}
+	if items[0].From != "1.3.0" || items[0].To != "v1.3.0" {
+		t.Fatalf("expected 1.3.0 → v1.3.0, got %s → %s", items[0].From, items[0].To)
+	}
+}
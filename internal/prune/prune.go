@@ -0,0 +1,112 @@
+package prune
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AkaraChen/tagger/internal/semver"
+)
+
+// Options 选择要删除的预发布 tag 的条件，OlderThan 和 Superseded 满足其一即可删除
+type Options struct {
+	// OlderThan 删除创建时间早于该时长的预发布 tag，为 0 时不按时间删除
+	OlderThan time.Duration
+	// Superseded 删除已经有更高的正式版本的预发布 tag，例如发布 v1.3.0 之后的 v1.3.0-rc.2
+	Superseded bool
+	// Keep 每个预发布通道（例如 rc、nightly）保留的最新的 tag 数量
+	Keep int
+	// Now 当前时间，用于计算 tag 的存在时长
+	Now time.Time
+}
+
+// Candidate 将被删除的 tag
+type Candidate struct {
+	Tag semver.VersionTag
+	// Reasons 删除的原因，例如 "superseded by v1.3.0" 或 "42 days old"
+	Reasons []string
+}
+
+// Names 返回需要删除的 tag 名，包括解析为同一个版本的其他 tag
+func (c Candidate) Names() []string {
+	return append([]string{c.Tag.Name}, c.Tag.Duplicates...)
+}
+
+// Channel 返回预发布版本的通道，即预发布标识的第一部分，例如 1.3.0-rc.2 的 rc
+func Channel(prerelease string) string {
+	channel, _, _ := strings.Cut(prerelease, ".")
+	return channel
+}
+
+// Select 从一条版本线的 tags 中选出要删除的预发布 tag，结果按版本从低到高排序
+// 版本线中最新的版本始终保留，以免改变下一个版本的计算结果
+func Select(versionMgr *semver.VersionManager, tags []semver.VersionTag, opts Options) []Candidate {
+	if len(tags) == 0 {
+		return nil
+	}
+	latest := versionMgr.GetLatestVersion(tags)
+
+	var releases []semver.VersionTag
+	channels := make(map[string][]semver.VersionTag)
+	for _, tag := range tags {
+		if tag.Version.Prerelease() == "" {
+			releases = append(releases, tag)
+			continue
+		}
+		channel := Channel(tag.Version.Prerelease())
+		channels[channel] = append(channels[channel], tag)
+	}
+
+	var candidates []Candidate
+	for _, prereleases := range channels {
+		// 从新到旧排序，前 Keep 个保留
+		sort.Slice(prereleases, func(i, j int) bool {
+			return versionMgr.Scheme.Compare(prereleases[i].Version, prereleases[j].Version) > 0
+		})
+
+		for i, tag := range prereleases {
+			if i < opts.Keep || tag.Name == latest.Name {
+				continue
+			}
+
+			var reasons []string
+			if opts.Superseded {
+				if release := supersededBy(versionMgr, releases, tag); release != "" {
+					reasons = append(reasons, fmt.Sprintf("superseded by %s", release))
+				}
+			}
+			if opts.OlderThan > 0 && !tag.Info.Date.IsZero() {
+				if age := opts.Now.Sub(tag.Info.Date); age > opts.OlderThan {
+					reasons = append(reasons, fmt.Sprintf("%d days old", int(age.Hours()/24)))
+				}
+			}
+
+			if len(reasons) > 0 {
+				candidates = append(candidates, Candidate{Tag: tag, Reasons: reasons})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return versionMgr.Scheme.Compare(candidates[i].Tag.Version, candidates[j].Tag.Version) < 0
+	})
+	return candidates
+}
+
+// supersededBy 返回高于 tag 的最低正式版本的 tag 名，没有时返回空字符串
+func supersededBy(versionMgr *semver.VersionManager, releases []semver.VersionTag, tag semver.VersionTag) string {
+	var found *semver.VersionTag
+	for i, release := range releases {
+		if versionMgr.Scheme.Compare(release.Version, tag.Version) <= 0 {
+			continue
+		}
+		if found == nil || versionMgr.Scheme.Compare(release.Version, found.Version) < 0 {
+			found = &releases[i]
+		}
+	}
+	if found == nil {
+		return ""
+	}
+	return found.Name
+}
@@ -0,0 +1,88 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// IdentifierData preid 和 metadata 模板中可以使用的字段
+type IdentifierData struct {
+	// Date 当前日期，格式为 yyyymmdd，例如 20261017
+	Date string
+	// Timestamp 当前时间（UTC），格式为 yyyymmddhhmmss
+	Timestamp string
+	// Commit、ShortCommit HEAD 的完整 hash 和缩写
+	Commit      string
+	ShortCommit string
+	Branch      string
+	// Env 环境变量，例如 {{.Env.GITHUB_RUN_NUMBER}}
+	Env map[string]string
+}
+
+// RenderIdentifier 使用 text/template 渲染 preid 或 metadata，不包含模板语法的文本原样返回
+func RenderIdentifier(name, text string, data IdentifierData) (string, error) {
+	// 未设置的环境变量渲染为空字符串
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// WithPrerelease 返回 v 的正式版本加上预发布标识 preid 的版本
+// preid 的最后一部分为数字时原样使用（例如 nightly.20261017），否则追加从 0 开始的序号，
+// 序号根据 tags 中相同正式版本和 preid 的预发布版本递增，例如 beta.0、beta.1
+func (vm *VersionManager) WithPrerelease(v *semver.Version, preid string, tags []VersionTag) (*semver.Version, error) {
+	if preid == "" {
+		return v, nil
+	}
+
+	parts := strings.Split(preid, ".")
+	if _, err := strconv.ParseUint(parts[len(parts)-1], 10, 64); err != nil {
+		next := 0
+		for _, tag := range tags {
+			existing := tag.Version
+			if existing.Major() != v.Major() || existing.Minor() != v.Minor() || existing.Patch() != v.Patch() {
+				continue
+			}
+			counter, ok := strings.CutPrefix(existing.Prerelease(), preid+".")
+			if !ok {
+				continue
+			}
+			if n, err := strconv.Atoi(counter); err == nil && n >= next {
+				next = n + 1
+			}
+		}
+		preid = fmt.Sprintf("%s.%d", preid, next)
+	}
+
+	base := semver.New(v.Major(), v.Minor(), v.Patch(), "", v.Metadata())
+	result, err := base.SetPrerelease(preid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prerelease identifier %q: %w", preid, err)
+	}
+	return &result, nil
+}
+
+// WithMetadata 返回带有构建元数据的版本，例如 1.2.3+build.45；metadata 为空时返回 v
+func (vm *VersionManager) WithMetadata(v *semver.Version, metadata string) (*semver.Version, error) {
+	if metadata == "" {
+		return v, nil
+	}
+
+	result, err := v.SetMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid build metadata %q: %w", metadata, err)
+	}
+	return &result, nil
+}
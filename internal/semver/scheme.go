@@ -0,0 +1,274 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// BumpType 版本选择中的一种更新类型
+type BumpType struct {
+	Type  string // 例如 patch、minor、major 或 release
+	Label string // 列表中的说明，例如 "补丁更新"
+}
+
+// Scheme 版本方案，决定版本号的解析、比较、格式化和递增方式
+// 所有方案都使用 semver.Version 保存版本的各个部分，以便共用排序和 tag 解析的逻辑
+type Scheme interface {
+	// Name 方案的名称，例如 semver 或 calver
+	Name() string
+	// Parse 解析不包含 tag 前缀的版本号
+	Parse(s string) (*semver.Version, error)
+	// Compare 比较两个版本，a < b 时返回负数，相等时返回 0，a > b 时返回正数
+	Compare(a, b *semver.Version) int
+	// Format 将版本格式化为不包含 tag 前缀的字符串
+	Format(v *semver.Version) string
+	// Next 根据更新类型计算下一个版本
+	Next(current *semver.Version, bumpType string) (*semver.Version, error)
+	// BumpTypes 版本选择中提供的更新类型
+	BumpTypes() []BumpType
+}
+
+// SemVer 语义化版本方案
+type SemVer struct{}
+
+func (SemVer) Name() string {
+	return "semver"
+}
+
+func (SemVer) Parse(s string) (*semver.Version, error) {
+	return semver.NewVersion(s)
+}
+
+func (SemVer) Compare(a, b *semver.Version) int {
+	return a.Compare(b)
+}
+
+func (SemVer) Format(v *semver.Version) string {
+	return v.String()
+}
+
+func (SemVer) Next(current *semver.Version, bumpType string) (*semver.Version, error) {
+	var next semver.Version
+	switch strings.ToLower(bumpType) {
+	case "major":
+		next = current.IncMajor()
+	case "minor":
+		next = current.IncMinor()
+	case "patch":
+		next = current.IncPatch()
+	default:
+		return nil, fmt.Errorf("invalid bump type: %s (must be major, minor, or patch)", bumpType)
+	}
+	return &next, nil
+}
+
+func (SemVer) BumpTypes() []BumpType {
+	return []BumpType{
+		{Type: "patch", Label: "补丁更新"},
+		{Type: "minor", Label: "小版本更新"},
+		{Type: "major", Label: "大版本更新"},
+	}
+}
+
+// DefaultCalVerFormat 未配置 calverFormat 时使用的格式
+const DefaultCalVerFormat = "YYYY.0M.MICRO"
+
+// calVerTokens 支持的格式 token，value 为零填充的宽度，0 表示不填充
+var calVerTokens = map[string]int{
+	"YYYY":  0,
+	"YY":    0,
+	"0Y":    2,
+	"MM":    0,
+	"0M":    2,
+	"WW":    0,
+	"0W":    2,
+	"DD":    0,
+	"0D":    2,
+	"MICRO": 0,
+}
+
+// CalVer 日历版本方案，例如 YYYY.0M.MICRO 对应 2026.10.3
+// 格式最多包含三个以 . 分隔的 token，依次保存在 major、minor、patch 中；MICRO 只能是最后一个 token
+type CalVer struct {
+	tokens []string
+	// Now 返回当前时间，为 nil 时使用 time.Now
+	Now func() time.Time
+}
+
+// NewCalVer 解析日历版本格式
+func NewCalVer(format string) (*CalVer, error) {
+	tokens := strings.Split(format, ".")
+	if len(tokens) > 3 {
+		return nil, fmt.Errorf("invalid calver format %q: at most three segments are supported", format)
+	}
+
+	for i, token := range tokens {
+		if _, ok := calVerTokens[token]; !ok {
+			return nil, fmt.Errorf("invalid calver format %q: unknown token %q (supported: YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MICRO)", format, token)
+		}
+		if token == "MICRO" && i != len(tokens)-1 {
+			return nil, fmt.Errorf("invalid calver format %q: MICRO must be the last segment", format)
+		}
+	}
+	if tokens[0] == "MICRO" {
+		return nil, fmt.Errorf("invalid calver format %q: the first segment must be a date token", format)
+	}
+
+	return &CalVer{tokens: tokens}, nil
+}
+
+func (c *CalVer) Name() string {
+	return "calver"
+}
+
+// String 返回格式，例如 YYYY.0M.MICRO
+func (c *CalVer) String() string {
+	return strings.Join(c.tokens, ".")
+}
+
+func (c *CalVer) Parse(s string) (*semver.Version, error) {
+	core, suffix := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, suffix = s[:i], s[i:]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != len(c.tokens) {
+		return nil, fmt.Errorf("version %s does not match calver format %s", s, c)
+	}
+
+	var values [3]uint64
+	for i, part := range parts {
+		// 零填充的 token 和四位年份要求位数一致，避免把 1.2.3 这样的语义化版本当作日历版本
+		width := calVerTokens[c.tokens[i]]
+		if c.tokens[i] == "YYYY" {
+			width = 4
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil || (width > 0 && len(part) != width) {
+			return nil, fmt.Errorf("version %s does not match calver format %s", s, c)
+		}
+		values[i] = n
+	}
+
+	// 交给 semver 解析预发布和构建元数据
+	return semver.NewVersion(fmt.Sprintf("%d.%d.%d%s", values[0], values[1], values[2], suffix))
+}
+
+func (c *CalVer) Compare(a, b *semver.Version) int {
+	return a.Compare(b)
+}
+
+func (c *CalVer) Format(v *semver.Version) string {
+	values := [3]uint64{v.Major(), v.Minor(), v.Patch()}
+
+	parts := make([]string, len(c.tokens))
+	for i, token := range c.tokens {
+		if width := calVerTokens[token]; width > 0 {
+			parts[i] = fmt.Sprintf("%0*d", width, values[i])
+		} else {
+			parts[i] = strconv.FormatUint(values[i], 10)
+		}
+	}
+
+	s := strings.Join(parts, ".")
+	if v.Prerelease() != "" {
+		s += "-" + v.Prerelease()
+	}
+	if v.Metadata() != "" {
+		s += "+" + v.Metadata()
+	}
+	return s
+}
+
+// Next release 使用当前日期，日期与当前版本相同时递增 MICRO；micro 保持当前版本的日期只递增 MICRO
+func (c *CalVer) Next(current *semver.Version, bumpType string) (*semver.Version, error) {
+	date := c.date()
+	hasMicro := c.tokens[len(c.tokens)-1] == "MICRO"
+	dateSegments := len(c.tokens)
+	if hasMicro {
+		dateSegments--
+	}
+
+	currentValues := [3]uint64{current.Major(), current.Minor(), current.Patch()}
+	var next [3]uint64
+
+	switch strings.ToLower(bumpType) {
+	case "release":
+		copy(next[:], date[:dateSegments])
+		samePeriod := true
+		for i := 0; i < dateSegments; i++ {
+			if next[i] != currentValues[i] {
+				samePeriod = false
+			}
+		}
+		if samePeriod {
+			if !hasMicro {
+				return nil, fmt.Errorf("version %s already exists for the current period", c.Format(current))
+			}
+			// 预发布版本的下一个版本是其正式版本
+			next[dateSegments] = currentValues[dateSegments]
+			if current.Prerelease() == "" {
+				next[dateSegments]++
+			}
+		}
+	case "micro":
+		if !hasMicro {
+			return nil, fmt.Errorf("calver format %s has no MICRO segment", c)
+		}
+		next = currentValues
+		if current.Prerelease() == "" {
+			next[dateSegments]++
+		}
+	default:
+		return nil, fmt.Errorf("invalid bump type: %s (must be release or micro)", bumpType)
+	}
+
+	return semver.New(next[0], next[1], next[2], "", ""), nil
+}
+
+func (c *CalVer) BumpTypes() []BumpType {
+	types := []BumpType{{Type: "release", Label: "按当前日期发布"}}
+	if c.tokens[len(c.tokens)-1] == "MICRO" {
+		types = append(types, BumpType{Type: "micro", Label: "保持日期，递增 MICRO"})
+	}
+	return types
+}
+
+// date 返回当前日期对应的各个 token 的值，使用周的格式时年份为 ISO 年
+func (c *CalVer) date() [3]uint64 {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	t := now()
+	isoYear, week := t.ISOWeek()
+
+	year := t.Year()
+	for _, token := range c.tokens {
+		if token == "WW" || token == "0W" {
+			year = isoYear
+		}
+	}
+
+	var values [3]uint64
+	for i, token := range c.tokens {
+		switch token {
+		case "YYYY":
+			values[i] = uint64(year)
+		case "YY", "0Y":
+			values[i] = uint64(year % 100)
+		case "MM", "0M":
+			values[i] = uint64(t.Month())
+		case "WW", "0W":
+			values[i] = uint64(week)
+		case "DD", "0D":
+			values[i] = uint64(t.Day())
+		}
+	}
+	return values
+}
@@ -1,59 +1,216 @@
 package semver
 
 import (
+	"cmp"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/AkaraChen/tagger/internal/git"
 	"github.com/Masterminds/semver/v3"
 )
 
-// VersionManager 管理语义化版本
-type VersionManager struct{}
+// DefaultPrefix 默认的 tag 前缀
+const DefaultPrefix = "v"
 
-// NewVersionManager 创建一个新的 VersionManager
+// VersionManager 管理 tag 中的版本号
+type VersionManager struct {
+	// Prefix tag 名中版本号之前的部分，例如 v、api/v 或 @scope/pkg@
+	Prefix string
+	// Scheme 版本方案，默认为语义化版本
+	Scheme Scheme
+	// InitialDevelopment 为 true 时 0.x 版本处于初始开发阶段：
+	// major（不兼容的更新）递增次版本，minor（新功能）递增补丁版本，1.0.0 只能通过 stabilize 发布
+	InitialDevelopment bool
+}
+
+// VersionTag 解析为版本的 tag
+type VersionTag struct {
+	Version *semver.Version
+	// Name 原始的 tag 名，例如 v1.2.3、1.2.3 或 api/v1.2.3；GetLatestVersion 在没有 tag 时返回空字符串
+	Name string
+	// Prefix tag 名中版本号之前的部分，默认前缀可省略时可能为空
+	Prefix string
+	Info   git.TagInfo
+	// Duplicates 解析为同一个版本但没有被使用的其他 tag 名
+	Duplicates []string
+}
+
+// Tagged 判断版本是否有对应的 tag
+func (t VersionTag) Tagged() bool {
+	return t.Name != ""
+}
+
+// NewVersionManager 创建一个新的 VersionManager，使用默认的 v 前缀
 func NewVersionManager() *VersionManager {
-	return &VersionManager{}
+	return NewPrefixedVersionManager(DefaultPrefix)
 }
 
-// ParseTags 解析 tags，返回符合 semver 格式的版本列表
-func (vm *VersionManager) ParseTags(tags []string) ([]*semver.Version, error) {
-	var versions []*semver.Version
+// NewPrefixedVersionManager 创建使用指定 tag 前缀的 VersionManager
+func NewPrefixedVersionManager(prefix string) *VersionManager {
+	return &VersionManager{Prefix: prefix, Scheme: SemVer{}}
+}
 
-	for _, tag := range tags {
-		// 移除 v 前缀（如果有）
-		versionStr := strings.TrimPrefix(tag, "v")
+// IsSemVer 判断是否使用语义化版本方案，Go 模块相关的检查只适用于语义化版本
+func (vm *VersionManager) IsSemVer() bool {
+	_, ok := vm.Scheme.(SemVer)
+	return ok
+}
 
-		// 尝试解析
-		v, err := semver.NewVersion(versionStr)
+// ParseTags 解析 tags，返回符合版本方案的 tag，顺序与 tags 一致
+// 只有构建元数据不同或前缀不同的 tag（例如 v1.2.3、1.2.3 和 v1.2.3+build.45）是同一个版本，只保留一个：
+// 优先保留没有构建元数据的 tag，否则保留构建元数据最大的 tag，其余的 tag 名记录在 Duplicates 中
+func (vm *VersionManager) ParseTags(tags []git.TagInfo) ([]VersionTag, error) {
+	var versionTags []VersionTag
+	index := make(map[string]int)
+
+	for _, info := range tags {
+		v, prefix, err := vm.parseTag(info.Name)
 		if err != nil {
 			// 跳过不符合 semver 格式的 tag
 			continue
 		}
+		tag := VersionTag{Version: v, Name: info.Name, Prefix: prefix, Info: info}
+
+		key := precedenceKey(v)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(versionTags)
+			versionTags = append(versionTags, tag)
+			continue
+		}
+
+		// 构建元数据也相同时优先保留使用配置的前缀的 tag，例如 v1.2.3 而不是 1.2.3
+		kept := &versionTags[i]
+		preferPrefix := v.Metadata() == kept.Version.Metadata() && prefix == vm.Prefix && kept.Prefix != vm.Prefix
+		if preferVersion(v, kept.Version) || preferPrefix {
+			tag.Duplicates = append(kept.Duplicates, kept.Name)
+			*kept = tag
+		} else {
+			kept.Duplicates = append(kept.Duplicates, tag.Name)
+		}
+	}
+
+	return versionTags, nil
+}
+
+// precedenceKey 返回忽略构建元数据的版本，用于判断两个版本是否相同
+func precedenceKey(v *semver.Version) string {
+	key := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+	if v.Prerelease() != "" {
+		key += "-" + v.Prerelease()
+	}
+	return key
+}
+
+// preferVersion 判断同一个版本的两个 tag 中是否应该保留 a
+func preferVersion(a, b *semver.Version) bool {
+	if a.Metadata() == "" || b.Metadata() == "" {
+		return a.Metadata() == "" && b.Metadata() != ""
+	}
+	return compareMetadata(a.Metadata(), b.Metadata()) > 0
+}
+
+// compareMetadata 按 . 分隔的部分比较构建元数据，数字部分按数值比较，例如 build.10 > build.9
+func compareMetadata(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// ParseVersion 解析单个 tag 或版本字符串，规则与 ParseTags 一致
+func (vm *VersionManager) ParseVersion(tag string) (*semver.Version, error) {
+	v, _, err := vm.parseTag(tag)
+	return v, err
+}
 
-		versions = append(versions, v)
+// parseTag 解析 tag，同时返回 tag 中实际使用的前缀
+func (vm *VersionManager) parseTag(tag string) (*semver.Version, string, error) {
+	prefix := vm.Prefix
+	// 默认的 v 前缀是可选的
+	if vm.Prefix == DefaultPrefix && !strings.HasPrefix(tag, DefaultPrefix) {
+		prefix = ""
 	}
 
-	return versions, nil
+	// 其他前缀必须完全匹配，以区分 monorepo 中不同包的 tag
+	if !strings.HasPrefix(tag, prefix) {
+		return nil, "", fmt.Errorf("tag %s does not start with prefix %q", tag, vm.Prefix)
+	}
+	v, err := vm.Scheme.Parse(strings.TrimPrefix(tag, prefix))
+	if err != nil {
+		return nil, "", err
+	}
+	return v, prefix, nil
+}
+
+// ParseInput 解析用户输入的版本号，前缀是可选的，例如 2.0.0、v2.0.0 或 api/v2.0.0
+func (vm *VersionManager) ParseInput(input string) (*semver.Version, error) {
+	if v, err := vm.ParseVersion(input); err == nil {
+		return v, nil
+	}
+	return vm.Scheme.Parse(input)
 }
 
-// GetLatestVersion 获取最新版本，如果没有版本则返回 v0.0.0
-func (vm *VersionManager) GetLatestVersion(versions []*semver.Version) *semver.Version {
-	if len(versions) == 0 {
+// GetLatestVersion 获取最新版本的 tag，如果没有版本则返回 Name 为空的 v0.0.0
+func (vm *VersionManager) GetLatestVersion(tags []VersionTag) VersionTag {
+	if len(tags) == 0 {
 		// 默认从 v0.0.0 开始
-		v, _ := semver.NewVersion("0.0.0")
-		return v
+		return VersionTag{Version: semver.New(0, 0, 0, "", ""), Prefix: vm.Prefix}
 	}
 
-	latest := versions[0]
-	for _, v := range versions[1:] {
-		if v.GreaterThan(latest) {
-			latest = v
+	latest := tags[0]
+	for _, tag := range tags[1:] {
+		// 只有构建元数据不同时与 ParseTags 保留的版本一致
+		if c := vm.Scheme.Compare(tag.Version, latest.Version); c > 0 || (c == 0 && preferVersion(tag.Version, latest.Version)) {
+			latest = tag
 		}
 	}
 
 	return latest
 }
 
+// GetPreviousVersion 返回 tags 中版本小于 v 的最大版本，没有时返回 nil
+func (vm *VersionManager) GetPreviousVersion(tags []VersionTag, v *semver.Version) *VersionTag {
+	var previous *VersionTag
+	for i, candidate := range tags {
+		if vm.Scheme.Compare(candidate.Version, v) < 0 && (previous == nil || vm.Scheme.Compare(candidate.Version, previous.Version) > 0) {
+			previous = &tags[i]
+		}
+	}
+	return previous
+}
+
+// FindTag 返回版本与 v 相等的 tag，优先返回构建元数据也相同的 tag
+func (vm *VersionManager) FindTag(tags []VersionTag, v *semver.Version) (VersionTag, bool) {
+	var found *VersionTag
+	for i, tag := range tags {
+		if vm.Scheme.Compare(tag.Version, v) != 0 {
+			continue
+		}
+		if tag.Version.Metadata() == v.Metadata() {
+			return tag, true
+		}
+		if found == nil {
+			found = &tags[i]
+		}
+	}
+	if found == nil {
+		return VersionTag{}, false
+	}
+	return *found, true
+}
+
 // BumpMajor 递增主版本号
 func (vm *VersionManager) BumpMajor(v *semver.Version) *semver.Version {
 	newVersion := v.IncMajor()
@@ -72,21 +229,106 @@ func (vm *VersionManager) BumpPatch(v *semver.Version) *semver.Version {
 	return &newVersion
 }
 
-// FormatVersion 格式化版本号为 vX.Y.Z 格式
+// FormatVersion 格式化版本号为 <prefix>X.Y.Z 格式，默认为 vX.Y.Z
 func (vm *VersionManager) FormatVersion(v *semver.Version) string {
-	return fmt.Sprintf("v%s", v.String())
+	return fmt.Sprintf("%s%s", vm.Prefix, vm.VersionString(v))
+}
+
+// VersionString 返回不包含 tag 前缀的版本号，例如 1.2.3 或 2026.01.3
+func (vm *VersionManager) VersionString(v *semver.Version) string {
+	return vm.Scheme.Format(v)
+}
+
+// StabilizeBumpType 初始开发阶段发布 1.0.0 的更新类型
+const StabilizeBumpType = "stabilize"
+
+// InInitialDevelopment 判断 v 是否处于初始开发阶段（开启 InitialDevelopment 的 0.x 语义化版本）
+func (vm *VersionManager) InInitialDevelopment(v *semver.Version) bool {
+	return vm.InitialDevelopment && vm.IsSemVer() && v.Major() == 0
+}
+
+// Stabilizes 判断从 current 更新到 target 是否结束初始开发阶段，即从 0.x 发布 1.0.0 或更高的版本
+func (vm *VersionManager) Stabilizes(current, target *semver.Version) bool {
+	return vm.InInitialDevelopment(current) && target.Major() > 0
+}
+
+// BumpTypes 返回版本选择中提供的更新类型，初始开发阶段的说明反映 0.x 的递增规则并额外提供 stabilize
+func (vm *VersionManager) BumpTypes(current *semver.Version) []BumpType {
+	if !vm.InInitialDevelopment(current) {
+		return vm.Scheme.BumpTypes()
+	}
+	return []BumpType{
+		{Type: "patch", Label: "补丁更新"},
+		{Type: "minor", Label: "新功能，0.x 中递增补丁版本"},
+		{Type: "major", Label: "不兼容的更新，0.x 中递增次版本"},
+		{Type: StabilizeBumpType, Label: "结束初始开发，发布稳定版本"},
+	}
 }
 
-// CalculateNewVersion 根据更新类型计算新版本号
+// CalculateNewVersion 根据更新类型计算新版本号，可用的更新类型由版本方案决定
 func (vm *VersionManager) CalculateNewVersion(current *semver.Version, bumpType string) (*semver.Version, error) {
-	switch strings.ToLower(bumpType) {
+	bumpType = strings.ToLower(bumpType)
+	if !vm.InInitialDevelopment(current) {
+		if bumpType == StabilizeBumpType {
+			return nil, fmt.Errorf("%s is only available for 0.x versions in initial development", StabilizeBumpType)
+		}
+		return vm.Scheme.Next(current, bumpType)
+	}
+
+	switch bumpType {
 	case "major":
-		return vm.BumpMajor(current), nil
-	case "minor":
 		return vm.BumpMinor(current), nil
-	case "patch":
+	case "minor", "patch":
 		return vm.BumpPatch(current), nil
+	case StabilizeBumpType:
+		return semver.New(1, 0, 0, "", ""), nil
 	default:
-		return nil, fmt.Errorf("invalid bump type: %s (must be major, minor, or patch)", bumpType)
+		return vm.Scheme.Next(current, bumpType)
+	}
+}
+
+// CheckCustomVersion 检查自定义版本相对于当前版本是否合法
+// 返回的 warning 描述被跳过的版本；force 为 true 时允许不大于当前版本的版本
+func (vm *VersionManager) CheckCustomVersion(current, target *semver.Version, force bool) (warning string, err error) {
+	if vm.Scheme.Compare(target, current) <= 0 {
+		if !force {
+			return "", fmt.Errorf("%s is not greater than current version %s (use --force to allow)",
+				vm.FormatVersion(target), vm.FormatVersion(current))
+		}
+		return fmt.Sprintf("%s is not greater than current version %s", vm.FormatVersion(target), vm.FormatVersion(current)), nil
 	}
+
+	// 跳过版本的检查只适用于语义化版本
+	if !vm.IsSemVer() {
+		return "", nil
+	}
+
+	// 只比较 major.minor.patch，预发布版本视为其正式版本的一部分
+	base := func(v *semver.Version) *semver.Version {
+		return semver.New(v.Major(), v.Minor(), v.Patch(), "", "")
+	}
+	currentBase, targetBase := base(current), base(target)
+
+	// 当前为预发布版本时，同一个正式版本是合法的下一个版本
+	if current.Prerelease() != "" && targetBase.Equal(currentBase) {
+		return "", nil
+	}
+
+	var expected *semver.Version
+	var level string
+	switch {
+	case targetBase.Major() != currentBase.Major():
+		expected, level = vm.BumpMajor(currentBase), "major"
+	case targetBase.Minor() != currentBase.Minor():
+		expected, level = vm.BumpMinor(currentBase), "minor"
+	default:
+		expected, level = vm.BumpPatch(currentBase), "patch"
+	}
+
+	if targetBase.Equal(expected) {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s skips versions: the next %s version would be %s",
+		vm.FormatVersion(target), level, vm.FormatVersion(expected)), nil
 }
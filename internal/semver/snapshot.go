@@ -0,0 +1,71 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"golang.org/x/mod/module"
+)
+
+// SnapshotData snapshot 模板中可以使用的字段
+type SnapshotData struct {
+	// Prefix tag 前缀，例如 v
+	Prefix string
+	// Current 最新的版本，没有 tag 时为 0.0.0
+	Current string
+	// NextPatch、NextMinor、NextMajor 下一个补丁、次、主版本，不包含前缀
+	NextPatch string
+	NextMinor string
+	NextMajor string
+	// Commit、ShortCommit HEAD 的完整 hash 和缩写
+	Commit      string
+	ShortCommit string
+	// Distance 自最新的 tag 以来的 commit 数量
+	Distance int
+	// Timestamp HEAD 的提交时间（UTC），格式为 yyyymmddhhmmss
+	Timestamp string
+	Branch    string
+}
+
+// NewSnapshotData 根据最新版本填充版本相关的字段
+func (vm *VersionManager) NewSnapshotData(current *semver.Version) SnapshotData {
+	return SnapshotData{
+		Prefix:    vm.Prefix,
+		Current:   vm.VersionString(current),
+		NextPatch: vm.VersionString(vm.BumpPatch(current)),
+		NextMinor: vm.VersionString(vm.BumpMinor(current)),
+		NextMajor: vm.VersionString(vm.BumpMajor(current)),
+	}
+}
+
+// PseudoVersion 返回 Go 格式的伪版本，例如 v1.4.3-0.20261017120000-abcdef123456
+// latest 为 nil 表示还没有 tag；Go 模块的版本不包含目录前缀，因此结果始终以 v 开头
+func (vm *VersionManager) PseudoVersion(latest *semver.Version, t time.Time, commit string) string {
+	older := ""
+	if latest != nil {
+		older = "v" + latest.String()
+	}
+
+	rev := commit
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	return module.PseudoVersion("", older, t, rev)
+}
+
+// RenderSnapshot 使用 text/template 模板渲染 snapshot 版本
+func RenderSnapshot(text string, data SnapshotData) (string, error) {
+	tmpl, err := template.New("snapshot").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid snapshot template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render snapshot template: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,464 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// InitAnswers tagger init 向导中的选择
+type InitAnswers struct {
+	Provider       config.GitHostingProvider
+	OpenActionPage bool
+	TagPrefix      string
+	Packages       []config.PackageConfig
+	Sign           bool
+	Push           config.PushMode
+	Changelog      bool
+}
+
+// InitWizardOptions tagger init 向导的参数
+type InitWizardOptions struct {
+	// Defaults 自动检测到的默认值，Packages 为默认选中的包
+	Defaults InitAnswers
+	// Packages 扫描到的所有包
+	Packages []config.PackageConfig
+	// Detected 显示在向导顶部的检测结果，例如 "Remote: https://github.com/x/y"
+	Detected []string
+	// Path 将要写入的配置文件
+	Path string
+}
+
+// RunInitWizard 运行 tagger init 向导
+func RunInitWizard(opts InitWizardOptions) (InitAnswers, error) {
+	m := newInitWizardModel(opts)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return InitAnswers{}, err
+	}
+
+	if m, ok := finalModel.(initWizardModel); ok {
+		if m.cancelled {
+			return InitAnswers{}, fmt.Errorf("cancelled")
+		}
+		return m.answers, nil
+	}
+
+	return InitAnswers{}, fmt.Errorf("unexpected error")
+}
+
+// initStep init 向导的步骤
+type initStep int
+
+const (
+	initStepProvider initStep = iota
+	initStepActionPage
+	initStepPrefix
+	initStepPackages
+	initStepSign
+	initStepPush
+	initStepChangelog
+	initStepReview
+)
+
+// initChoice 单选步骤中的一项
+type initChoice struct {
+	value string
+	desc  string
+}
+
+var providerChoices = []initChoice{
+	{value: string(config.GitHub), desc: "打开 GitHub Actions 页面或仓库主页"},
+	{value: string(config.Other), desc: "推送后询问是否打开仓库"},
+}
+
+var pushChoices = []initChoice{
+	{value: string(config.PushAsk), desc: "每次创建 tag 后询问"},
+	{value: string(config.PushAlways), desc: "总是推送到远程"},
+	{value: string(config.PushNever), desc: "从不推送"},
+}
+
+// initWizardModel tagger init 向导的 Model
+type initWizardModel struct {
+	opts    InitWizardOptions
+	answers InitAnswers
+	step    initStep
+	history []initStep
+	cursor  int
+	// selected 包选择步骤中每个包是否选中
+	selected []bool
+	input    textinput.Model
+
+	quitting  bool
+	cancelled bool
+}
+
+func newInitWizardModel(opts InitWizardOptions) initWizardModel {
+	ti := textinput.New()
+	ti.Prompt = "› "
+	ti.CharLimit = 64
+	ti.SetValue(opts.Defaults.TagPrefix)
+
+	selected := make([]bool, len(opts.Packages))
+	for i, pkg := range opts.Packages {
+		for _, d := range opts.Defaults.Packages {
+			if d.Path == pkg.Path {
+				selected[i] = true
+			}
+		}
+	}
+
+	m := initWizardModel{
+		opts:     opts,
+		answers:  opts.Defaults,
+		step:     initStepProvider,
+		selected: selected,
+		input:    ti,
+	}
+	m.cursor = m.defaultCursor()
+	return m
+}
+
+func (m initWizardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m initWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			m.quitting = true
+			return m, tea.Quit
+		case "shift+tab":
+			return m.back()
+		}
+	}
+
+	switch m.step {
+	case initStepProvider:
+		return m.updateSelect(msg, providerChoices)
+	case initStepPush:
+		return m.updateSelect(msg, pushChoices)
+	case initStepPrefix:
+		return m.updatePrefix(msg)
+	case initStepPackages:
+		return m.updatePackages(msg)
+	case initStepActionPage, initStepSign, initStepChangelog:
+		return m.updateConfirm(msg)
+	case initStepReview:
+		return m.updateReview(msg)
+	}
+
+	return m, nil
+}
+
+// next 返回当前步骤之后需要显示的步骤
+func (m initWizardModel) next() initStep {
+	step := m.step + 1
+	if step == initStepActionPage && m.answers.Provider != config.GitHub {
+		step++
+	}
+	if step == initStepPackages && len(m.opts.Packages) == 0 {
+		step++
+	}
+	return step
+}
+
+// advance 前进到下一个步骤，并记录历史以便返回
+func (m initWizardModel) advance() (tea.Model, tea.Cmd) {
+	m.history = append(m.history, m.step)
+	m.step = m.next()
+	m.cursor = m.defaultCursor()
+	return m.focus()
+}
+
+// back 返回上一个步骤
+func (m initWizardModel) back() (tea.Model, tea.Cmd) {
+	if len(m.history) == 0 {
+		return m, nil
+	}
+
+	m.step = m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.cursor = m.defaultCursor()
+	return m.focus()
+}
+
+func (m initWizardModel) focus() (tea.Model, tea.Cmd) {
+	if m.step == initStepPrefix {
+		return m, m.input.Focus()
+	}
+	m.input.Blur()
+	return m, nil
+}
+
+// defaultCursor 单选步骤中光标的初始位置为当前答案
+func (m initWizardModel) defaultCursor() int {
+	var choices []initChoice
+	var value string
+	switch m.step {
+	case initStepProvider:
+		choices, value = providerChoices, string(m.answers.Provider)
+	case initStepPush:
+		choices, value = pushChoices, string(m.answers.Push)
+	}
+
+	for i, c := range choices {
+		if c.value == value {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m initWizardModel) updateSelect(msg tea.Msg, choices []initChoice) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(choices)-1 {
+			m.cursor++
+		}
+	case "enter":
+		value := choices[m.cursor].value
+		if m.step == initStepProvider {
+			m.answers.Provider = config.GitHostingProvider(value)
+		} else {
+			m.answers.Push = config.PushMode(value)
+		}
+		return m.advance()
+	}
+	return m, nil
+}
+
+func (m initWizardModel) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	// 当前步骤对应的答案，enter 保留默认值
+	value := map[initStep]*bool{
+		initStepActionPage: &m.answers.OpenActionPage,
+		initStepSign:       &m.answers.Sign,
+		initStepChangelog:  &m.answers.Changelog,
+	}[m.step]
+
+	switch key.String() {
+	case "y", "Y":
+		*value = true
+	case "n", "N":
+		*value = false
+	case "enter":
+	default:
+		return m, nil
+	}
+	return m.advance()
+}
+
+func (m initWizardModel) updatePrefix(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
+		m.answers.TagPrefix = strings.TrimSpace(m.input.Value())
+		return m.advance()
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m initWizardModel) updatePackages(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.opts.Packages)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		m.selected[m.cursor] = !m.selected[m.cursor]
+	case "a":
+		all := true
+		for _, s := range m.selected {
+			all = all && s
+		}
+		for i := range m.selected {
+			m.selected[i] = !all
+		}
+	case "enter":
+		m.answers.Packages = nil
+		for i, pkg := range m.opts.Packages {
+			if m.selected[i] {
+				m.answers.Packages = append(m.answers.Packages, pkg)
+			}
+		}
+		return m.advance()
+	}
+	return m, nil
+}
+
+func (m initWizardModel) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "y", "Y", "enter":
+		m.quitting = true
+		return m, tea.Quit
+	case "n", "N":
+		m.cancelled = true
+		m.quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m initWizardModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	lines := []string{TitleStyle.Render("Tagger Setup")}
+	for _, d := range m.opts.Detected {
+		lines = append(lines, HelpStyle.Render(d))
+	}
+	lines = append(lines, "")
+
+	switch m.step {
+	case initStepProvider:
+		lines = append(lines, PromptStyle.Render("Git hosting provider?"), "")
+		lines = append(lines, m.selectView(providerChoices)...)
+	case initStepActionPage:
+		lines = append(lines, m.confirmView("Open the GitHub Actions page after pushing (instead of the homepage)?", m.answers.OpenActionPage))
+	case initStepPrefix:
+		lines = append(lines,
+			PromptStyle.Render("Tag prefix?"),
+			HelpStyle.Render(fmt.Sprintf("Tags will look like %s1.2.3", m.input.Value())),
+			"",
+			m.input.View())
+	case initStepPackages:
+		lines = append(lines, PromptStyle.Render("Tag these packages independently?"), "")
+		lines = append(lines, m.packagesView()...)
+	case initStepSign:
+		lines = append(lines, m.confirmView("Sign tags with GPG (git tag -s)?", m.answers.Sign))
+	case initStepPush:
+		lines = append(lines, PromptStyle.Render("Push tags after creating them?"), "")
+		lines = append(lines, m.selectView(pushChoices)...)
+	case initStepChangelog:
+		lines = append(lines, m.confirmView("Include the generated changelog in tag messages?", m.answers.Changelog))
+	case initStepReview:
+		lines = append(lines, m.reviewView()...)
+	}
+
+	return "\n" + strings.Join(lines, "\n") + "\n\n" + HelpStyle.Render(m.helpText())
+}
+
+func (m initWizardModel) selectView(choices []initChoice) []string {
+	var lines []string
+	for i, c := range choices {
+		if i == m.cursor {
+			lines = append(lines, SelectedStyle.Render("› "+c.value)+"  "+HelpStyle.Render(c.desc))
+		} else {
+			lines = append(lines, "  "+c.value+"  "+HelpStyle.Render(c.desc))
+		}
+	}
+	return lines
+}
+
+func (m initWizardModel) packagesView() []string {
+	var lines []string
+	for i, pkg := range m.opts.Packages {
+		check := "[ ]"
+		if m.selected[i] {
+			check = "[x]"
+		}
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = SelectedStyle.Render("› ")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s %s  %s", cursor, check, pkg.Name,
+			HelpStyle.Render(fmt.Sprintf("%s (tags: %s1.2.3)", pkg.Path, pkg.Prefix()))))
+	}
+	return lines
+}
+
+func (m initWizardModel) confirmView(prompt string, defaultValue bool) string {
+	defaultIndicator := "[y/N]"
+	if defaultValue {
+		defaultIndicator = "[Y/n]"
+	}
+	return fmt.Sprintf("%s %s", PromptStyle.Render(prompt), HelpStyle.Render(defaultIndicator))
+}
+
+func (m initWizardModel) reviewView() []string {
+	a := m.answers
+	lines := []string{
+		PromptStyle.Render("Review"),
+		summaryRow("Provider", string(a.Provider)),
+	}
+	if a.Provider == config.GitHub {
+		lines = append(lines, summaryRow("Actions", yesNo(a.OpenActionPage)))
+	}
+	lines = append(lines,
+		summaryRow("Prefix", fmt.Sprintf("%q", a.TagPrefix)),
+		summaryRow("Sign", yesNo(a.Sign)),
+		summaryRow("Push", string(a.Push)),
+		summaryRow("Changes", yesNo(a.Changelog)),
+	)
+	for _, pkg := range a.Packages {
+		lines = append(lines, summaryRow("Package", fmt.Sprintf("%s (%s)", pkg.Name, pkg.Prefix())))
+	}
+
+	lines = append(lines, "", m.confirmView(fmt.Sprintf("Write %s?", m.opts.Path), true))
+	return lines
+}
+
+func (m initWizardModel) helpText() string {
+	var help string
+	switch m.step {
+	case initStepProvider, initStepPush:
+		help = "↑/↓ select • enter confirm"
+	case initStepPrefix:
+		help = "enter confirm"
+	case initStepPackages:
+		help = "↑/↓ move • space toggle • a toggle all • enter confirm"
+	default:
+		help = "y/n answer • enter default"
+	}
+
+	if len(m.history) > 0 {
+		help += " • shift+tab back"
+	}
+	return help + " • esc cancel"
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,757 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/editor"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BumpChoice 版本选择列表中的一项
+type BumpChoice struct {
+	Type    string // 由版本方案决定，例如 patch、minor、major
+	Version string // 选择后的新版本，例如 v1.2.4
+	Label   string // 列表中的说明，例如 "补丁更新"
+}
+
+// Recommendation 分析得出的建议更新类型
+type Recommendation struct {
+	Type   string // patch、minor、major
+	Reason string // 简短的原因，例如 "incompatible API changes since v1.2.0"
+	Report string // 详细报告，在版本选择步骤中显示
+}
+
+// TagWizardOptions 创建 tag 向导的参数
+type TagWizardOptions struct {
+	CurrentVersion string
+	Choices        []BumpChoice
+	// Recommendation 不为 nil 时默认选中建议的更新类型并显示报告
+	Recommendation *Recommendation
+	// ValidateCustom 校验自定义版本，返回规范化后的版本和警告；为 nil 时不提供自定义选项
+	ValidateCustom func(input string) (version, warning string, err error)
+	// DefaultMessage 根据新版本生成 textarea 的默认内容
+	DefaultMessage func(version string) string
+	// MessageByDefault 为 true 时默认添加 message（配置了 messageTemplate），
+	// 此时 DefaultMessage 返回空字符串表示创建 lightweight tag，不再询问
+	MessageByDefault bool
+	// Changelog 自上个版本以来的变更日志，打开编辑器时附加在默认内容之后
+	Changelog string
+	// Editor 用于编辑 message 的编辑器命令，为空时不提供编辑器
+	Editor string
+	// CommentChar git 的 core.commentChar 配置
+	CommentChar string
+	// OpenEditor 为 true 时直接在编辑器中编辑 message，而不是使用 textarea
+	OpenEditor bool
+	// Message 通过 -m 传入的 message，不为空时跳过 message 相关步骤
+	Message string
+	// Commit 将被打 tag 的 commit，例如 "abc1234 feat: add diff"
+	Commit string
+	// AskPush 为 true 时询问是否推送，否则使用 Push 的值
+	AskPush bool
+	Push    bool
+	Remote  string
+	DryRun  bool
+	// Sign 为 true 时创建 GPG 签名的 tag
+	Sign bool
+}
+
+// TagWizardResult 向导的结果
+type TagWizardResult struct {
+	BumpType string // 版本方案提供的更新类型或 custom
+	Version  string
+	Warning  string
+	Message  string
+	Push     bool
+}
+
+// RunTagWizard 在同一个 Bubble Tea 程序中完成版本选择、message 输入、推送选择和最终确认
+func RunTagWizard(opts TagWizardOptions) (TagWizardResult, error) {
+	m := newTagWizardModel(opts)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return TagWizardResult{}, err
+	}
+
+	if m, ok := finalModel.(tagWizardModel); ok {
+		if m.cancelled {
+			return TagWizardResult{}, fmt.Errorf("cancelled")
+		}
+		return m.result(), nil
+	}
+
+	return TagWizardResult{}, fmt.Errorf("unexpected error")
+}
+
+// wizardStep 向导的步骤
+type wizardStep int
+
+const (
+	stepBump wizardStep = iota
+	stepCustom
+	stepAddMessage
+	stepMessage
+	stepPush
+	stepReview
+)
+
+// stepTitles 各步骤的标题
+var stepTitles = map[wizardStep]string{
+	stepBump:       "Version",
+	stepCustom:     "Version",
+	stepAddMessage: "Message",
+	stepMessage:    "Message",
+	stepPush:       "Push",
+	stepReview:     "Review",
+}
+
+// sidebarWidth 摘要侧边栏的宽度
+const sidebarWidth = 34
+
+// maxReportLines 版本选择步骤中显示的报告行数
+const maxReportLines = 8
+
+// bumpRank 更新类型的严重程度，用于和建议的类型比较
+var bumpRank = map[string]int{"patch": 1, "minor": 2, "major": 3, "stabilize": 4}
+
+// customBumpType 自定义版本的类型
+const customBumpType = "custom"
+
+// tagWizardModel 创建 tag 向导的 Model
+type tagWizardModel struct {
+	opts     TagWizardOptions
+	step     wizardStep
+	history  []wizardStep
+	list     list.Model
+	input    textinput.Model
+	textarea textarea.Model
+
+	choice         BumpChoice
+	custom         BumpChoice
+	customWarning  string
+	customErr      error
+	addMessage     bool
+	message        string
+	defaultMessage string
+	editorErr      error
+	push           bool
+
+	width     int
+	height    int
+	quitting  bool
+	cancelled bool
+}
+
+func newTagWizardModel(opts TagWizardOptions) tagWizardModel {
+	items := make([]list.Item, 0, len(opts.Choices))
+	selected := 0
+	for i, c := range opts.Choices {
+		desc := fmt.Sprintf("%s → %s (%s)", opts.CurrentVersion, c.Version, c.Label)
+		if opts.Recommendation != nil && opts.Recommendation.Type == c.Type {
+			desc += " ★ recommended"
+			selected = i
+		}
+		items = append(items, item{
+			title: c.Type,
+			desc:  desc,
+		})
+	}
+	if opts.ValidateCustom != nil {
+		items = append(items, item{
+			title: customBumpType,
+			desc:  "Custom… (输入任意版本号)",
+		})
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = fmt.Sprintf("Current Version: %s", opts.CurrentVersion)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.Styles.Title = TitleStyle
+	l.Select(selected)
+
+	ti := textinput.New()
+	ti.Placeholder = "2.0.0-beta.1"
+	ti.Prompt = "› "
+	ti.CharLimit = 64
+
+	ta := textarea.New()
+	ta.Placeholder = "Enter tag message..."
+	ta.SetWidth(60)
+	ta.SetHeight(5)
+
+	return tagWizardModel{
+		opts:     opts,
+		step:     stepBump,
+		list:     l,
+		input:    ti,
+		textarea: ta,
+		message:  opts.Message,
+		push:     opts.Push,
+	}
+}
+
+func (m tagWizardModel) result() TagWizardResult {
+	r := TagWizardResult{Message: m.message, Push: m.push}
+	r.BumpType = m.choice.Type
+	r.Version = m.choice.Version
+	if m.choice.Type == customBumpType {
+		r.Warning = m.customWarning
+	}
+	return r
+}
+
+func (m tagWizardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m tagWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.list.SetSize(m.mainWidth(), msg.Height-4-m.recommendationHeight())
+		m.textarea.SetWidth(min(60, m.mainWidth()-4))
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			m.quitting = true
+			return m, tea.Quit
+
+		case "shift+tab":
+			return m.back()
+		}
+	}
+
+	switch m.step {
+	case stepBump:
+		return m.updateBump(msg)
+	case stepCustom:
+		return m.updateCustom(msg)
+	case stepAddMessage:
+		return m.updateAddMessage(msg)
+	case stepMessage:
+		return m.updateMessage(msg)
+	case stepPush:
+		return m.updatePush(msg)
+	case stepReview:
+		return m.updateReview(msg)
+	}
+
+	return m, nil
+}
+
+// goTo 前进到指定步骤，并记录历史以便返回
+func (m tagWizardModel) goTo(step wizardStep) (tea.Model, tea.Cmd) {
+	m.history = append(m.history, m.step)
+	m.step = step
+	return m.focus()
+}
+
+// back 返回上一个步骤
+func (m tagWizardModel) back() (tea.Model, tea.Cmd) {
+	if len(m.history) == 0 {
+		return m, nil
+	}
+
+	m.step = m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	return m.focus()
+}
+
+// focus 根据当前步骤切换输入框的焦点
+func (m tagWizardModel) focus() (tea.Model, tea.Cmd) {
+	m.input.Blur()
+	m.textarea.Blur()
+
+	switch m.step {
+	case stepCustom:
+		return m, m.input.Focus()
+	case stepMessage:
+		m.textarea.Focus()
+		return m, textarea.Blink
+	}
+	return m, nil
+}
+
+// afterMessage message 确定后的下一步
+func (m tagWizardModel) afterMessage() (tea.Model, tea.Cmd) {
+	if m.opts.AskPush {
+		return m.goTo(stepPush)
+	}
+	return m.goTo(stepReview)
+}
+
+func (m tagWizardModel) updateBump(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
+		index := m.list.Index()
+		if index == len(m.opts.Choices) && m.opts.ValidateCustom != nil {
+			return m.goTo(stepCustom)
+		}
+		if index < 0 || index >= len(m.opts.Choices) {
+			return m, nil
+		}
+		m.choice = m.opts.Choices[index]
+		return m.afterBump()
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// afterBump 版本确定后的下一步
+func (m tagWizardModel) afterBump() (tea.Model, tea.Cmd) {
+	if m.opts.Message != "" {
+		return m.afterMessage()
+	}
+	if m.opts.MessageByDefault && m.opts.DefaultMessage != nil && m.opts.DefaultMessage(m.choice.Version) == "" {
+		m.message = ""
+		return m.afterMessage()
+	}
+	return m.goTo(stepAddMessage)
+}
+
+func (m tagWizardModel) updateCustom(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
+		if m.customErr != nil || strings.TrimSpace(m.input.Value()) == "" {
+			return m, nil
+		}
+		m.choice = m.custom
+		return m.afterBump()
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	// 每次输入后重新校验
+	version, warning, err := m.opts.ValidateCustom(strings.TrimSpace(m.input.Value()))
+	m.custom = BumpChoice{Type: customBumpType, Version: version, Label: "自定义"}
+	m.customWarning = warning
+	m.customErr = err
+
+	return m, cmd
+}
+
+func (m tagWizardModel) updateAddMessage(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "y", "Y":
+		m.addMessage = true
+	case "n", "N":
+		m.addMessage = false
+	case "enter":
+		m.addMessage = m.opts.MessageByDefault
+	default:
+		return m, nil
+	}
+
+	if !m.addMessage {
+		m.message = ""
+		return m.afterMessage()
+	}
+
+	// 版本变化后重新生成默认内容，但保留用户已修改的内容
+	if m.opts.DefaultMessage != nil {
+		defaultMessage := m.opts.DefaultMessage(m.choice.Version)
+		if m.textarea.Value() == "" || m.textarea.Value() == m.defaultMessage {
+			m.textarea.SetValue(defaultMessage)
+		}
+		m.defaultMessage = defaultMessage
+	}
+
+	model, cmd := m.goTo(stepMessage)
+	if m.opts.OpenEditor && m.opts.Editor != "" {
+		return model.(tagWizardModel).openEditor()
+	}
+	return model, cmd
+}
+
+// editorFinishedMsg 编辑器退出后的消息
+type editorFinishedMsg struct {
+	path        string
+	commentChar byte
+	err         error
+}
+
+// openEditor 将当前 message 写入临时文件并在编辑器中打开
+func (m tagWizardModel) openEditor() (tea.Model, tea.Cmd) {
+	content := m.textarea.Value()
+
+	// 默认内容未修改时，附加生成的变更日志
+	if m.opts.Changelog != "" && (content == "" || content == m.defaultMessage) {
+		content = strings.TrimRight(content, " ") + "\n\n" + m.opts.Changelog
+	}
+
+	commentChar := editor.CommentChar(m.opts.CommentChar, content)
+	path, err := editor.WriteTemp(content, commentChar, []string{
+		fmt.Sprintf("Please enter the message for tag %s.", m.choice.Version),
+		fmt.Sprintf("Lines starting with '%c' will be ignored.", commentChar),
+	})
+	if err != nil {
+		m.editorErr = err
+		return m, nil
+	}
+
+	return m, tea.ExecProcess(editor.Command(m.opts.Editor, path), func(err error) tea.Msg {
+		return editorFinishedMsg{path: path, commentChar: commentChar, err: err}
+	})
+}
+
+// handleEditorFinished 读取编辑结果，成功后进入确认步骤
+func (m tagWizardModel) handleEditorFinished(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		os.Remove(msg.path)
+		m.editorErr = fmt.Errorf("editor exited with error: %w", msg.err)
+		return m.focus()
+	}
+
+	message, err := editor.ReadResult(msg.path, msg.commentChar)
+	if err != nil {
+		m.editorErr = err
+		return m.focus()
+	}
+	if message == "" {
+		m.editorErr = fmt.Errorf("empty message, keeping the previous content")
+		return m.focus()
+	}
+
+	m.editorErr = nil
+	m.textarea.SetValue(message)
+	m.message = message
+	return m.afterMessage()
+}
+
+func (m tagWizardModel) updateMessage(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if finished, ok := msg.(editorFinishedMsg); ok {
+		return m.handleEditorFinished(finished)
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyCtrlD:
+			m.message = strings.TrimSpace(m.textarea.Value())
+			return m.afterMessage()
+		case tea.KeyCtrlE:
+			if m.opts.Editor != "" {
+				return m.openEditor()
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func (m tagWizardModel) updatePush(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "y", "Y", "enter":
+		m.push = true
+	case "n", "N":
+		m.push = false
+	default:
+		return m, nil
+	}
+
+	return m.goTo(stepReview)
+}
+
+func (m tagWizardModel) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "y", "Y", "enter":
+		m.quitting = true
+		return m, tea.Quit
+	case "n", "N":
+		m.cancelled = true
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m tagWizardModel) mainWidth() int {
+	if m.width == 0 {
+		return 80
+	}
+	return max(40, m.width-sidebarWidth-2)
+}
+
+func (m tagWizardModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var content string
+	switch m.step {
+	case stepBump:
+		content = m.list.View()
+		if m.opts.Recommendation != nil {
+			content += "\n" + m.recommendationView()
+		}
+	case stepCustom:
+		content = m.customView()
+	case stepAddMessage:
+		content = m.confirmView("Add a tag message?", m.opts.MessageByDefault)
+	case stepMessage:
+		content = TitleStyle.Render("Tag Message") + "\n\n" +
+			m.textarea.View() + "\n\n" +
+			HelpStyle.Render(fmt.Sprintf("%d characters", len(m.textarea.Value())))
+		if m.editorErr != nil {
+			content += "\n" + ErrorStyle.Render(fmt.Sprintf("✗ %v", m.editorErr))
+		}
+	case stepPush:
+		content = m.confirmView(fmt.Sprintf("Push tag %s to %s?", m.choice.Version, m.remoteName()), true)
+	case stepReview:
+		content = m.reviewView()
+	}
+
+	main := lipgloss.NewStyle().Width(m.mainWidth()).Render(m.headerView() + "\n\n" + content)
+	body := lipgloss.JoinHorizontal(lipgloss.Top, main, m.sidebarView())
+
+	return "\n" + body + "\n\n" + HelpStyle.Render(m.helpText())
+}
+
+func (m tagWizardModel) headerView() string {
+	steps := []wizardStep{stepBump, stepAddMessage, stepPush, stepReview}
+	active := m.step
+	switch active {
+	case stepCustom:
+		active = stepBump
+	case stepMessage:
+		active = stepAddMessage
+	}
+
+	var parts []string
+	for _, s := range steps {
+		if s == stepPush && !m.opts.AskPush {
+			continue
+		}
+		if s == stepAddMessage && m.opts.Message != "" {
+			continue
+		}
+
+		title := stepTitles[s]
+		if s == active {
+			parts = append(parts, SelectedStyle.Render(title))
+		} else {
+			parts = append(parts, HelpStyle.Render(title))
+		}
+	}
+
+	header := strings.Join(parts, HelpStyle.Render(" › "))
+	if m.opts.DryRun {
+		header += HelpStyle.Render("  (dry run)")
+	}
+	return header
+}
+
+func (m tagWizardModel) confirmView(prompt string, defaultValue bool) string {
+	defaultIndicator := "[y/N]"
+	if defaultValue {
+		defaultIndicator = "[Y/n]"
+	}
+	return fmt.Sprintf("%s %s", InfoStyle.Render(prompt), HelpStyle.Render(defaultIndicator))
+}
+
+func (m tagWizardModel) customView() string {
+	lines := []string{
+		TitleStyle.Render("Custom Version"),
+		HelpStyle.Render(fmt.Sprintf("Current: %s", m.opts.CurrentVersion)),
+		"",
+		m.input.View(),
+		"",
+	}
+
+	switch {
+	case strings.TrimSpace(m.input.Value()) == "":
+		lines = append(lines, HelpStyle.Render("Enter a semantic version, e.g. v2.0.0-beta.1"))
+	case m.customErr != nil:
+		lines = append(lines, ErrorStyle.Render(fmt.Sprintf("✗ %v", m.customErr)))
+	case m.customWarning != "":
+		lines = append(lines, SuccessStyle.Render(fmt.Sprintf("→ %s", m.custom.Version)))
+		lines = append(lines, InfoStyle.Render(fmt.Sprintf("⚠ %s", m.customWarning)))
+	default:
+		lines = append(lines, SuccessStyle.Render(fmt.Sprintf("→ %s", m.custom.Version)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m tagWizardModel) reviewView() string {
+	tagType := "lightweight"
+	switch {
+	case m.opts.Sign:
+		tagType = "signed"
+	case m.message != "":
+		tagType = "annotated"
+	}
+
+	lines := []string{
+		TitleStyle.Render("Review"),
+		fmt.Sprintf("%s %s → %s (%s)", PromptStyle.Render("Tag:    "), m.opts.CurrentVersion, SelectedStyle.Render(m.choice.Version), tagType),
+		fmt.Sprintf("%s %s", PromptStyle.Render("Target: "), m.opts.Commit),
+		fmt.Sprintf("%s %s", PromptStyle.Render("Push:   "), m.pushPlan()),
+	}
+
+	if m.choice.Type == customBumpType && m.customWarning != "" {
+		lines = append(lines, InfoStyle.Render(fmt.Sprintf("⚠ %s", m.customWarning)))
+	}
+
+	if rec := m.opts.Recommendation; rec != nil && bumpRank[m.choice.Type] != 0 && bumpRank[m.choice.Type] < bumpRank[rec.Type] {
+		lines = append(lines, InfoStyle.Render(fmt.Sprintf("⚠ %s is recommended: %s", rec.Type, rec.Reason)))
+	}
+
+	if m.message != "" {
+		lines = append(lines, PromptStyle.Render("Message:"))
+		lines = append(lines, BorderStyle.Padding(0, 1).Render(m.message))
+	}
+
+	lines = append(lines, "", m.confirmView(fmt.Sprintf("Create tag %s?", m.choice.Version), true))
+	return strings.Join(lines, "\n")
+}
+
+// recommendationView 显示建议的更新类型和报告的前几行
+func (m tagWizardModel) recommendationView() string {
+	rec := m.opts.Recommendation
+	lines := []string{InfoStyle.Render(fmt.Sprintf("★ Recommended: %s (%s)", rec.Type, rec.Reason))}
+
+	report := strings.Split(rec.Report, "\n")
+	if len(report) > maxReportLines {
+		more := len(report) - maxReportLines
+		report = append(report[:maxReportLines], fmt.Sprintf("… %d more lines", more))
+	}
+	for _, line := range report {
+		lines = append(lines, HelpStyle.Render(truncate(line, m.mainWidth()-2)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recommendationHeight 版本选择步骤中报告占用的行数
+func (m tagWizardModel) recommendationHeight() int {
+	if m.opts.Recommendation == nil {
+		return 0
+	}
+	return lipgloss.Height(m.recommendationView()) + 1
+}
+
+func (m tagWizardModel) sidebarView() string {
+	newVersion := "—"
+	if m.choice.Type != "" && m.step > stepCustom {
+		newVersion = fmt.Sprintf("%s (%s)", m.choice.Version, m.choice.Type)
+	}
+
+	message := "—"
+	switch {
+	case m.message != "" && (m.step > stepMessage || m.opts.Message != ""):
+		message = truncate(firstLine(m.message), sidebarWidth-14)
+	case m.step > stepMessage:
+		message = "(lightweight)"
+	}
+
+	push := "—"
+	if !m.opts.AskPush || m.step > stepPush {
+		push = m.pushPlan()
+	}
+
+	rows := []string{
+		TitleStyle.Render("Summary"),
+		summaryRow("Current", m.opts.CurrentVersion),
+		summaryRow("New", newVersion),
+	}
+	if m.opts.Recommendation != nil {
+		rows = append(rows, summaryRow("Suggest", m.opts.Recommendation.Type))
+	}
+	rows = append(rows,
+		summaryRow("Message", message),
+		summaryRow("Push", push),
+		summaryRow("Commit", truncate(m.opts.Commit, sidebarWidth-14)),
+	)
+
+	return BorderStyle.Width(sidebarWidth).Render(strings.Join(rows, "\n"))
+}
+
+func (m tagWizardModel) pushPlan() string {
+	switch {
+	case m.opts.Remote == "":
+		return "no remote"
+	case m.push:
+		return fmt.Sprintf("push to %s", m.opts.Remote)
+	default:
+		return "do not push"
+	}
+}
+
+func (m tagWizardModel) remoteName() string {
+	if m.opts.Remote == "" {
+		return "remote"
+	}
+	return m.opts.Remote
+}
+
+func (m tagWizardModel) helpText() string {
+	var help string
+	switch m.step {
+	case stepBump:
+		help = "↑/↓ select • enter confirm"
+	case stepCustom:
+		help = "enter confirm"
+	case stepMessage:
+		help = "Ctrl+D to finish"
+		if m.opts.Editor != "" {
+			help += " • Ctrl+E open editor"
+		}
+	default:
+		help = "y/n answer • enter default"
+	}
+
+	if len(m.history) > 0 {
+		help += " • shift+tab back"
+	}
+	return help + " • esc cancel"
+}
+
+func summaryRow(label, value string) string {
+	return fmt.Sprintf("%s %s", HelpStyle.Render(fmt.Sprintf("%-8s", label)), value)
+}
+
+func firstLine(s string) string {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,74 @@
+package versionfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/AkaraChen/tagger/internal/config"
+)
+
+// Metadata 写入生成的 Go 版本文件的发布信息
+type Metadata struct {
+	// Version 不包含 tag 前缀的版本号，例如 1.2.3
+	Version string
+	// Commit 生成文件时 HEAD 的完整 hash，release commit 的父 commit
+	Commit string
+	// Date RFC 3339 格式的生成时间
+	Date string
+	// PreviousVersion 上一个版本，没有时为空字符串
+	PreviousVersion string
+}
+
+var goTemplate = template.Must(template.New("version").Parse(`// Code generated by tagger; DO NOT EDIT.
+
+package {{.Package}}
+
+const (
+	// Version is the released version without the tag prefix.
+	Version = {{printf "%q" .Version}}
+	// Commit is the commit the release was cut from.
+	Commit = {{printf "%q" .Commit}}
+	// Date is the time the file was generated, in RFC 3339 format.
+	Date = {{printf "%q" .Date}}
+	// PreviousVersion is the version released before Version, empty for the first release.
+	PreviousVersion = {{printf "%q" .PreviousVersion}}
+)
+`))
+
+// GenerateGo 计算生成的 Go 版本文件的内容，不写入文件，文件不存在时 Old 为空
+func GenerateGo(root string, f config.GoVersionFile, meta Metadata) (Change, error) {
+	pkg := f.PackageName()
+	if !token.IsIdentifier(pkg) {
+		return Change{}, fmt.Errorf("%s: invalid Go package name %q (set goVersionFile.package)", f.Path, pkg)
+	}
+
+	change := Change{Path: f.Path}
+
+	data, err := os.ReadFile(filepath.Join(root, change.Path))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Change{}, fmt.Errorf("failed to read version file: %w", err)
+	}
+	change.Old = string(data)
+
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, struct {
+		Package string
+		Metadata
+	}{pkg, meta}); err != nil {
+		return Change{}, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return Change{}, fmt.Errorf("%s: failed to format generated code: %w", change.Path, err)
+	}
+	change.New = string(src)
+
+	return change, nil
+}
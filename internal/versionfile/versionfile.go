@@ -0,0 +1,362 @@
+package versionfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AkaraChen/tagger/internal/config"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultKey json、yaml 和 toml 格式未配置 key 时使用的字段
+const DefaultKey = "version"
+
+// Change 一个文件的修改
+type Change struct {
+	// Path 相对于仓库根目录的路径
+	Path string
+	Old  string
+	New  string
+}
+
+// Changed 判断文件内容是否发生了变化
+func (c Change) Changed() bool {
+	return c.Old != c.New
+}
+
+// Diff 返回修改的 unified diff，只包含变化的行
+// 版本号替换不会增删行，因此按行号逐行比较即可；行数变化时输出相同的开头和结尾之间的部分
+func (c Change) Diff() string {
+	oldLines := strings.Split(strings.TrimSuffix(c.Old, "\n"), "\n")
+	newLines := strings.Split(strings.TrimSuffix(c.New, "\n"), "\n")
+
+	var sb strings.Builder
+	if c.Old == "" {
+		fmt.Fprintf(&sb, "--- /dev/null\n+++ b/%s\n@@ -0,0 +1,%d @@\n", c.Path, len(newLines))
+		for _, line := range newLines {
+			sb.WriteString("+" + line + "\n")
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", c.Path, c.Path)
+	if len(oldLines) != len(newLines) {
+		// 去掉相同的开头和结尾，剩余部分作为一个 hunk
+		prefix := 0
+		for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+			prefix++
+		}
+		suffix := 0
+		for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+			oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+			suffix++
+		}
+
+		removed := oldLines[prefix : len(oldLines)-suffix]
+		added := newLines[prefix : len(newLines)-suffix]
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", prefix+1, len(removed), prefix+1, len(added))
+		for _, line := range removed {
+			sb.WriteString("-" + line + "\n")
+		}
+		for _, line := range added {
+			sb.WriteString("+" + line + "\n")
+		}
+		return sb.String()
+	}
+
+	for i := range oldLines {
+		if i >= len(newLines) || oldLines[i] == newLines[i] {
+			continue
+		}
+		fmt.Fprintf(&sb, "@@ -%d +%d @@\n-%s\n+%s\n", i+1, i+1, oldLines[i], newLines[i])
+	}
+	return sb.String()
+}
+
+// Update 计算将 f 中的版本号替换为 version 后的内容，不写入文件
+func Update(root string, f config.VersionFile, version string) (Change, error) {
+	data, err := os.ReadFile(filepath.Join(root, f.Path))
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to read version file: %w", err)
+	}
+
+	change := Change{Path: f.Path, Old: string(data)}
+
+	switch f.Format {
+	case config.VersionFileJSON:
+		change.New, err = replaceJSON(change.Old, keyOf(f), version)
+	case config.VersionFileYAML:
+		change.New, err = replaceYAML(change.Old, keyOf(f), version)
+	case config.VersionFileTOML:
+		change.New, err = replaceTOML(change.Old, keyOf(f), version)
+	case config.VersionFileRegex:
+		change.New, err = replaceRegex(change.Old, f.Pattern, version)
+	default:
+		err = fmt.Errorf("unsupported format %q", f.Format)
+	}
+	if err != nil {
+		return Change{}, fmt.Errorf("%s: %w", f.Path, err)
+	}
+
+	return change, nil
+}
+
+// Write 将修改写入文件，文件不存在时创建文件及其所在目录
+func Write(root string, c Change) error {
+	path := filepath.Join(root, c.Path)
+
+	perm := os.FileMode(0644)
+	info, err := os.Stat(path)
+	switch {
+	case err == nil:
+		perm = info.Mode().Perm()
+	case errors.Is(err, os.ErrNotExist):
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to write version file: %w", err)
+		}
+	default:
+		return fmt.Errorf("failed to write version file: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(c.New), perm); err != nil {
+		return fmt.Errorf("failed to write version file: %w", err)
+	}
+	return nil
+}
+
+func keyOf(f config.VersionFile) string {
+	if f.Key == "" {
+		return DefaultKey
+	}
+	return f.Key
+}
+
+// replaceAt 将 content 中 [start, end) 替换为 value
+func replaceAt(content string, start, end int, value string) string {
+	return content[:start] + value + content[end:]
+}
+
+// offsetOf 将从 1 开始的行列号（按字符计算列）转换为字节偏移量
+func offsetOf(content string, line, column int) int {
+	offset := 0
+	for l := 1; l < line; l++ {
+		i := strings.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return len(content)
+		}
+		offset += i + 1
+	}
+
+	for c := 1; c < column && offset < len(content); c++ {
+		_, size := decodeRune(content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func decodeRune(s string) (rune, int) {
+	for i, r := range s {
+		if i == 0 {
+			return r, len(string(r))
+		}
+	}
+	return 0, 1
+}
+
+// replaceJSON 替换 JSON 字符串字段，保留文件的其他格式
+func replaceJSON(content, key, version string) (string, error) {
+	root, err := config.ParseNode([]byte(content))
+	if err != nil {
+		return "", err
+	}
+
+	node := root
+	for _, part := range strings.Split(key, ".") {
+		member, ok := node.Get(part)
+		if !ok {
+			return "", fmt.Errorf("key %q not found", key)
+		}
+		node = member.Value
+	}
+	if node.Kind != config.StringNode {
+		return "", fmt.Errorf("key %q is not a string", key)
+	}
+
+	start := offsetOf(content, node.Line, node.Column)
+	end, err := jsonStringEnd(content, start)
+	if err != nil {
+		return "", err
+	}
+
+	quoted, _ := json.Marshal(version)
+	return replaceAt(content, start, end, string(quoted)), nil
+}
+
+// jsonStringEnd 返回从 start 开始的 JSON 字符串字面量的结束位置
+func jsonStringEnd(content string, start int) (int, error) {
+	if start >= len(content) || content[start] != '"' {
+		return 0, fmt.Errorf("expected a string at offset %d", start)
+	}
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// replaceYAML 替换 YAML 标量字段，保留注释和其他格式
+func replaceYAML(content, key, version string) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", err
+	}
+	if len(doc.Content) == 0 {
+		return "", fmt.Errorf("key %q not found", key)
+	}
+
+	node := doc.Content[0]
+	for _, part := range strings.Split(key, ".") {
+		var next *yaml.Node
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == part {
+					next = node.Content[i+1]
+				}
+			}
+		}
+		if next == nil {
+			return "", fmt.Errorf("key %q not found", key)
+		}
+		node = next
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("key %q is not a scalar", key)
+	}
+
+	start := offsetOf(content, node.Line, node.Column)
+	var end int
+	var err error
+	value := version
+
+	// 引号中的转义和折行使 node.Value 与原文的长度不同，因此从原文中找到结束的引号
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		end, err = jsonStringEnd(content, start)
+		value = `"` + version + `"`
+	case yaml.SingleQuotedStyle:
+		end, err = yamlSingleQuotedEnd(content, start)
+		value = "'" + version + "'"
+	case 0:
+		// 多行的 plain 标量在原文中与 node.Value 不同，无法定位时返回错误
+		end = start + len(node.Value)
+		if end > len(content) || content[start:end] != node.Value {
+			return "", fmt.Errorf("key %q: could not locate the value", key)
+		}
+		// 像 1.0 这样的版本号会被解析为数字，写入时加上引号
+		if node.Tag != "!!str" {
+			value = `"` + version + `"`
+		}
+	default:
+		return "", fmt.Errorf("key %q uses an unsupported YAML style (only plain and quoted scalars are supported)", key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("key %q: could not locate the value: %w", key, err)
+	}
+
+	return replaceAt(content, start, end, value), nil
+}
+
+// yamlSingleQuotedEnd 返回从 start 开始的 YAML 单引号字符串的结束位置，两个连续的单引号表示一个单引号
+func yamlSingleQuotedEnd(content string, start int) (int, error) {
+	if start >= len(content) || content[start] != '\'' {
+		return 0, fmt.Errorf("expected a string at offset %d", start)
+	}
+	for i := start + 1; i < len(content); i++ {
+		if content[i] != '\'' {
+			continue
+		}
+		if i+1 < len(content) && content[i+1] == '\'' {
+			i++
+			continue
+		}
+		return i + 1, nil
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// tomlTable 匹配 TOML 的表头，例如 [package]
+var tomlTable = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*(#.*)?$`)
+
+// tomlArrayTable 匹配 TOML 数组表的表头，例如 [[bin]]
+var tomlArrayTable = regexp.MustCompile(`^\s*\[\[([^\[\]]+)\]\]\s*(#.*)?$`)
+
+// replaceTOML 替换 TOML 字符串字段，key 的最后一段为字段名，前面的部分为表名
+func replaceTOML(content, key, version string) (string, error) {
+	var raw map[string]any
+	if _, err := toml.Decode(content, &raw); err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(key, ".")
+	table, field := strings.Join(parts[:len(parts)-1], "."), parts[len(parts)-1]
+	assignment := regexp.MustCompile(`^(\s*` + regexp.QuoteMeta(field) + `\s*=\s*)(["'])([^"']*)(["'])`)
+
+	// inArray 表示当前位于数组表中，key 无法指定数组的元素，其中的字段都不匹配
+	current, inArray := "", false
+	offset := 0
+	// 按原文的行遍历，offset 包含每行实际的换行符（\n 或 \r\n）
+	for _, raw := range strings.SplitAfter(content, "\n") {
+		line := strings.TrimRight(raw, "\r\n")
+		if m := tomlArrayTable.FindStringSubmatch(line); m != nil {
+			current, inArray = strings.TrimSpace(m[1]), true
+		} else if m := tomlTable.FindStringSubmatch(line); m != nil {
+			current, inArray = strings.TrimSpace(m[1]), false
+		} else if current == table && !inArray {
+			if loc := assignment.FindStringSubmatchIndex(line); loc != nil {
+				start, end := offset+loc[6], offset+loc[7]
+				return replaceAt(content, start, end, version), nil
+			}
+		}
+		offset += len(raw)
+	}
+
+	return "", fmt.Errorf("key %q not found", key)
+}
+
+// replaceRegex 将 pattern 所有匹配中第一个捕获组替换为 version
+func replaceRegex(content, pattern, version string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern: %w", err)
+	}
+	if re.NumSubexp() < 1 {
+		return "", fmt.Errorf("pattern %q has no capture group", pattern)
+	}
+
+	matches := re.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("pattern %q does not match", pattern)
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m[2] < 0 {
+			continue
+		}
+		sb.WriteString(content[last:m[2]])
+		sb.WriteString(version)
+		last = m[3]
+	}
+	sb.WriteString(content[last:])
+	return sb.String(), nil
+}